@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"ai-search/maze"
+)
+
+// RunResult holds the statistics for one solver run on one maze.
+type RunResult struct {
+	Maze         string        `json:"maze"`
+	Search       string        `json:"search"`
+	Run          int           `json:"run"`
+	Seed         int64         `json:"seed"`
+	Solved       bool          `json:"solved"`
+	PathLength   int           `json:"path_length"`
+	PathCost     float64       `json:"path_cost"`
+	OptimalCost  float64       `json:"optimal_cost"`
+	Optimal      bool          `json:"optimal"`
+	Explored     int           `json:"explored"`
+	MaxFrontier  int           `json:"max_frontier"`
+	WallTime     time.Duration `json:"wall_time_ns"`
+	Allocs       uint64        `json:"allocs"`
+	AllocedBytes uint64        `json:"alloced_bytes"`
+}
+
+// runCompare implements the compare command, which runs every registered
+// search strategy over one or more mazes and reports how they did.
+func runCompare(args []string) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	runs := fs.Int("runs", 1, "number of times to run each search on each maze")
+	movement := fs.String("movement", "", "movement model ("+strings.Join(maze.MovementNames(), ", ")+"), overriding each maze file's")
+	seed := fs.Int64("seed", 1, "random seed for the first run, incremented for each later run")
+	only := fs.String("search", "", "comma separated list of search types to compare (default all)")
+	csvFile := fs.String("csv", "", "write every run to this csv file")
+	jsonFile := fs.String("json", "", "write every run to this json file")
+	transpositions := fs.Bool("transpositions", true, "let iddfs and idastar remember the cheapest way to every cell; without it they can take minutes on the larger mazes")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: ai-search compare [flags] [maze files...]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files, _ = filepath.Glob("maze*.txt")
+	}
+
+	names := maze.StrategyNames()
+	if *only != "" {
+		names = nil
+		for name := range strings.SplitSeq(*only, ",") {
+			name = strings.TrimSpace(name)
+			if _, err := maze.LookupStrategy(name); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			names = append(names, name)
+		}
+	}
+
+	var model maze.Movement
+	if *movement != "" {
+		var err error
+		model, err = maze.LookupMovement(*movement)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	var results []RunResult
+	for _, file := range files {
+		fileResults, err := compareMaze(file, model, *transpositions, names, *runs, *seed)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		results = append(results, fileResults...)
+	}
+
+	printComparison(results)
+
+	if *csvFile != "" {
+		if err := writeResultsCSV(*csvFile, results); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	if *jsonFile != "" {
+		if err := writeResultsJSON(*jsonFile, results); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+}
+
+// compareMaze runs each named strategy on one maze file, checking every
+// solution against the optimal cost found by Dijkstra. A zero movement
+// leaves the one the maze file declares; transpositions is passed on to
+// IDDFS and IDA*.
+func compareMaze(file string, movement maze.Movement, transpositions bool, names []string, runs int, seed int64) ([]RunResult, error) {
+	m := maze.Maze{Movement: movement, Transpositions: transpositions}
+	if err := m.Load(file); err != nil {
+		return nil, err
+	}
+
+	optimal, solvable, err := optimalCost(&m)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []RunResult
+	for _, name := range names {
+		strategy, _ := maze.LookupStrategy(name)
+
+		for run := 1; run <= runs; run++ {
+			m.SetSeed(seed + int64(run-1))
+			result, err := measure(&m, strategy)
+			if err != nil {
+				return nil, fmt.Errorf("%s with %s: %s", file, name, err)
+			}
+			result.Maze = file
+			result.Run = run
+			result.Seed = seed + int64(run-1)
+
+			if solvable {
+				result.OptimalCost = optimal
+				result.Optimal = result.Solved && math.Abs(result.PathCost-optimal) < 1e-9
+			}
+
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+// optimalCost returns the cost of the cheapest path through the maze, and
+// whether there is a path at all.
+func optimalCost(m *maze.Maze) (float64, bool, error) {
+	strategy, _ := maze.LookupStrategy("dijkstra")
+	m.SetSeed(1)
+	if err := strategy.NewSolver(m).Solve(context.Background()); err != nil {
+		return 0, false, err
+	}
+	return m.Solution.Cost, len(m.Solution.Cells) > 0 || m.Start == m.Goal, nil
+}
+
+// measure solves the maze once with the given strategy, timing it and
+// counting the allocations it made.
+func measure(m *maze.Maze, strategy maze.Strategy) (RunResult, error) {
+	solver := strategy.NewSolver(m)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	startTime := time.Now()
+
+	err := solver.Solve(context.Background())
+
+	elapsed := time.Since(startTime)
+	runtime.ReadMemStats(&after)
+
+	return RunResult{
+		Search:       strategy.Name,
+		Solved:       len(m.Solution.Cells) > 0 || m.Start == m.Goal,
+		PathLength:   len(m.Solution.Cells),
+		PathCost:     m.Solution.Cost,
+		Explored:     m.NumExplored,
+		MaxFrontier:  m.MaxFrontier,
+		WallTime:     elapsed,
+		Allocs:       after.Mallocs - before.Mallocs,
+		AllocedBytes: after.TotalAlloc - before.TotalAlloc,
+	}, err
+}
+
+// printComparison prints a table with one row per maze and search, averaging
+// over repeated runs.
+func printComparison(results []RunResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "maze\tsearch\tlength\tcost\texplored\tmax frontier\ttime\tallocs\toptimal\t")
+
+	for i := 0; i < len(results); {
+		// group consecutive runs of the same search on the same maze
+		j := i
+		for j < len(results) && results[j].Maze == results[i].Maze && results[j].Search == results[i].Search {
+			j++
+		}
+		group := results[i:j]
+		i = j
+
+		var length, cost, explored float64
+		var elapsed time.Duration
+		var allocs uint64
+		maxFrontier, optimal, solved := 0, 0, 0
+		for _, r := range group {
+			length += float64(r.PathLength)
+			cost += r.PathCost
+			explored += float64(r.Explored)
+			elapsed += r.WallTime
+			allocs += r.Allocs
+			maxFrontier = max(maxFrontier, r.MaxFrontier)
+			if r.Optimal {
+				optimal++
+			}
+			if r.Solved {
+				solved++
+			}
+		}
+		n := float64(len(group))
+
+		verdict := "yes"
+		switch {
+		case solved == 0:
+			verdict = "no solution"
+		case optimal == 0:
+			verdict = "NO"
+		case optimal < len(group):
+			verdict = fmt.Sprintf("NO (%d/%d)", len(group)-optimal, len(group))
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%.1f\t%.1f\t%.1f\t%d\t%s\t%d\t%s\t\n",
+			group[0].Maze, group[0].Search, length/n, cost/n, explored/n, maxFrontier,
+			(elapsed / time.Duration(len(group))).Round(time.Microsecond), allocs/uint64(len(group)), verdict)
+	}
+
+	_ = w.Flush()
+}
+
+func writeResultsCSV(filename string, results []RunResult) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	_ = w.Write([]string{
+		"maze", "search", "run", "seed", "solved", "path_length", "path_cost", "optimal_cost", "optimal",
+		"explored", "max_frontier", "wall_time_ns", "allocs", "alloced_bytes",
+	})
+
+	for _, r := range results {
+		_ = w.Write([]string{
+			r.Maze,
+			r.Search,
+			strconv.Itoa(r.Run),
+			strconv.FormatInt(r.Seed, 10),
+			strconv.FormatBool(r.Solved),
+			strconv.Itoa(r.PathLength),
+			strconv.FormatFloat(r.PathCost, 'f', -1, 64),
+			strconv.FormatFloat(r.OptimalCost, 'f', -1, 64),
+			strconv.FormatBool(r.Optimal),
+			strconv.Itoa(r.Explored),
+			strconv.Itoa(r.MaxFrontier),
+			strconv.FormatInt(r.WallTime.Nanoseconds(), 10),
+			strconv.FormatUint(r.Allocs, 10),
+			strconv.FormatUint(r.AllocedBytes, 10),
+		})
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func writeResultsJSON(filename string, results []RunResult) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
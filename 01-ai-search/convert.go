@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"ai-search/maze"
+)
+
+// runConvert implements the convert command, which checks a maze file and
+// writes it back out in the version 2 text format or as JSON.
+func runConvert(args []string) {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	outfile := fs.String("out", "", "file to write the maze to (default stdout)")
+	format := fs.String("format", "", "format to write, text or json (default json when -out ends in .json, otherwise text)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: ai-search convert [flags] maze")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	if *format == "" {
+		*format = "text"
+		if strings.HasSuffix(*outfile, ".json") {
+			*format = "json"
+		}
+	}
+
+	var m maze.Maze
+	if err := m.Load(fs.Arg(0)); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	write := m.WriteText
+	switch *format {
+	case "text":
+	case "json":
+		write = m.WriteJSON
+	default:
+		fmt.Printf("invalid format %q, must be text or json\n", *format)
+		os.Exit(1)
+	}
+
+	out := os.Stdout
+	if *outfile != "" {
+		f, err := os.Create(*outfile)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := write(out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *outfile != "" {
+		fmt.Printf("wrote %dx%d maze to %s\n", m.Width, m.Height, *outfile)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"ai-search/maze"
+)
+
+// runGenerate implements the generate command.
+func runGenerate(args []string) {
+	var gen maze.Generator
+	var outfile string
+
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	fs.IntVar(&gen.Width, "width", 41, "maze width in characters")
+	fs.IntVar(&gen.Height, "height", 41, "maze height in characters")
+	fs.StringVar(&gen.Algorithm, "algorithm", "backtracker", "generation algorithm ("+strings.Join(maze.GeneratorNames(), ", ")+")")
+	fs.Float64Var(&gen.Braid, "braid", 0, "probability (0-1) of opening a loop at each dead end")
+	fs.Float64Var(&gen.Water, "water", 0, "fraction (0-1) of open cells to flood with water")
+	fs.Int64Var(&gen.Seed, "seed", 0, "random seed (0 picks one from the clock)")
+	fs.StringVar(&outfile, "out", "", "file to write the maze to (default stdout)")
+	_ = fs.Parse(args)
+
+	if gen.Seed == 0 {
+		gen.Seed = time.Now().UnixNano()
+	}
+
+	out := os.Stdout
+	if outfile != "" {
+		f, err := os.Create(outfile)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := gen.Write(out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if outfile != "" {
+		fmt.Printf("wrote %dx%d %s maze to %s (seed %d)\n", gen.Width|1, gen.Height|1, gen.Algorithm, outfile, gen.Seed)
+	}
+}
@@ -6,4 +6,4 @@ require golang.org/x/image v0.34.0
 
 require github.com/StephaneBunel/bresenham v0.0.0-20211027152503-ec76d7b8e923
 
-require github.com/kmicki/apng v0.0.0-20220730213738-34f389e0ac54 // indirect
+require github.com/kmicki/apng v0.0.0-20220730213738-34f389e0ac54
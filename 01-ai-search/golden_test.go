@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ai-search/maze"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the current results")
+
+// goldenMazes are the maze files checked against the golden files.
+var goldenMazes = []string{"maze.txt", "maze2.txt", "maze3.txt", "maze-flooded.txt"}
+
+// goldenSeed is the random seed every golden run uses.
+const goldenSeed = 1
+
+// TestGolden solves each maze with every registered strategy using a fixed
+// seed and checks the exact explored order and solution against the recorded
+// files in testdata/golden, so any change in solver behaviour shows up as a
+// diff. With -update the recorded files are rewritten instead.
+func TestGolden(t *testing.T) {
+	dir := filepath.Join("testdata", "golden")
+	if *update {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, file := range goldenMazes {
+		var m maze.Maze
+		if err := m.Load(file); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, name := range maze.StrategyNames() {
+			t.Run(file+"/"+name, func(t *testing.T) {
+				strategy, _ := maze.LookupStrategy(name)
+				m.SetSeed(goldenSeed)
+				if err := strategy.NewSolver(&m).Solve(context.Background()); err != nil {
+					t.Fatal(err)
+				}
+
+				got := goldenRecord(&m, file, name, goldenSeed)
+				golden := filepath.Join(dir, fmt.Sprintf("%s.%s.golden", strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)), name))
+
+				if *update {
+					if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := firstDifference(string(want), got); diff != "" {
+					t.Errorf("%s does not match: %s", golden, diff)
+				}
+			})
+		}
+	}
+}
+
+// goldenRecord writes out everything a solver produced in a stable, diff
+// friendly form: one solution step or explored cell per line.
+func goldenRecord(m *maze.Maze, file, name string, seed int64) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "maze: %s\n", filepath.Base(file))
+	fmt.Fprintf(&b, "search: %s\n", name)
+	fmt.Fprintf(&b, "seed: %d\n", seed)
+	fmt.Fprintf(&b, "solution: %d steps, cost %g\n", len(m.Solution.Cells), m.Solution.Cost)
+	for i, action := range m.Solution.Actions {
+		fmt.Fprintf(&b, "  %s [%d %d]\n", action, m.Solution.Cells[i].Row, m.Solution.Cells[i].Col)
+	}
+	fmt.Fprintf(&b, "explored: %d cells, %d expansions\n", len(m.Explored), m.NumExplored)
+	for _, p := range m.Explored {
+		fmt.Fprintf(&b, "  [%d %d]\n", p.Row, p.Col)
+	}
+
+	return b.String()
+}
+
+// firstDifference describes the first line at which want and got differ, or
+// returns an empty string if they are the same.
+func firstDifference(want, got string) string {
+	if want == got {
+		return ""
+	}
+
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	for i := range max(len(wantLines), len(gotLines)) {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return fmt.Sprintf("line %d: want %q, got %q", i+1, w, g)
+		}
+	}
+	return "files differ"
+}
@@ -1,273 +1,247 @@
 package main
 
 import (
-	"bufio"
-	"errors"
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"strings"
 	"time"
-)
 
-const (
-	DFS = iota
-	BFS
-	GBFS
-	ASTAR
-	DIJKSTRA
+	"ai-search/maze"
 )
 
-type Point struct {
-	Row   int
-	Col   int
-	Water bool
-}
-
-type Wall struct {
-	State Point
-	wall  bool
-}
-
-type Node struct {
-	index               int
-	State               Point
-	Parent              *Node
-	Action              string
-	CostToGoal          int
-	EstimatedCostToGoal float64
-}
-
-func (n *Node) ManhattanDistance(goal Point) int {
-	return abs(n.State.Row-goal.Row) + abs(n.State.Col-goal.Col)
-}
-
-type Solution struct {
-	Actions []string
-	Cells   []Point
-}
-
-type Maze struct {
-	Height      int
-	Width       int
-	Start       Point
-	Goal        Point
-	Walls       [][]Wall
-	CurrentNode *Node
-	Solution    Solution
-	Explored    []Point
-	Steps       int
-	NumExplored int
-	Debug       bool
-	SearchType  int
-	Animate     bool
-}
-
-func (g *Maze) Load(filename string) error {
-	f, err := os.Open(filename)
-	if err != nil {
-		return fmt.Errorf("error opening %s: %s\n", filename, err)
-	}
-	defer f.Close()
-
-	var fileContents []string
-
-	reader := bufio.NewReader(f)
-	for {
-		line, err := reader.ReadString('\n')
-		if err == io.EOF {
-			break
-		}
-
-		if err != nil {
-			return fmt.Errorf("cannot open file %s: %s", filename, err)
+func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "generate":
+			runGenerate(os.Args[2:])
+			return
+		case "compare":
+			runCompare(os.Args[2:])
+			return
+		case "convert":
+			runConvert(os.Args[2:])
+			return
+		case "replan":
+			runReplan(os.Args[2:])
+			return
+		case "race":
+			runRace(os.Args[2:])
+			return
+		case "serve":
+			runServe(os.Args[2:])
+			return
+		case "verify":
+			runVerify(os.Args[2:])
+			return
 		}
-
-		fileContents = append(fileContents, line)
 	}
 
-	foundStart, foundEnd := false, false
-	for _, line := range fileContents {
-		if strings.Contains(line, "A") {
-			foundStart = true
-		}
+	var m maze.Maze
+	var mazeFile, searchType, outfile, animationFile, solutionFile, treeFile, graphFile, movement, route, waypoints string
+	var seed int64
+	var animate bool
 
-		if strings.Contains(line, "B") {
-			foundEnd = true
-		}
-	}
+	animator := maze.NewAnimator()
 
-	if !foundStart {
-		return errors.New("starting location not found")
-	}
+	flag.StringVar(&mazeFile, "file", "maze.txt", "maze file")
+	flag.StringVar(&searchType, "search", "dfs", "search type ("+strings.Join(maze.StrategyNames(), ", ")+")")
+	flag.BoolVar(&m.Debug, "debug", false, "write debugging info")
+	flag.BoolVar(&animate, "animate", false, "produce animation")
+	flag.StringVar(&animationFile, "animation", "animation.png", "animation file to write (.gif for an animated gif, otherwise an animated png)")
+	flag.IntVar(&animator.Delay, "delay", animator.Delay, "delay between animation frames, in hundredths of a second")
+	flag.IntVar(&animator.Skip, "skip", animator.Skip, "only keep every n'th animation frame")
+	flag.StringVar(&outfile, "image", "image.png", "image of the solution to write (- for the terminal, empty for none)")
+	flag.StringVar(&solutionFile, "solution", "", "file to save the solution to as JSON, for verify (empty for none)")
+	flag.StringVar(&treeFile, "tree", "", "file to export the search tree to (.json for JSON, otherwise Graphviz DOT)")
+	flag.StringVar(&graphFile, "graph", "", "file to export the maze's graph of cells and moves to (.json for JSON, otherwise Graphviz DOT)")
+	overlay := addViewFlags(flag.CommandLine, &m.View)
+	flag.StringVar(&movement, "movement", "grid4", "movement model ("+strings.Join(maze.MovementNames(), ", ")+"), overriding the maze file's")
+	flag.BoolVar(&m.Transpositions, "transpositions", true, "let iddfs and idastar remember the cheapest way to every cell, using memory for the whole grid; without it they redo work exponentially on mazes with loops, and can take minutes on maze3.txt")
+	flag.Int64Var(&seed, "seed", 0, "random seed for the order neighbours are explored in (0 picks one from the clock)")
+	flag.StringVar(&route, "route", "waypoints", "how to visit several goals ("+strings.Join(maze.RouteNames(), ", ")+")")
+	flag.StringVar(&waypoints, "waypoints", "", "comma separated goal labels to visit in order, for the waypoints route (default every goal, then B)")
+	addLimitFlags(flag.CommandLine, &m.Limits)
+	flag.Parse()
 
-	if !foundEnd {
-		return errors.New("ending location not found")
+	if err := m.View.SetOverlays(*overlay); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
 
-	g.Height = len(fileContents)
-	g.Width = len(fileContents[0])
-
-	var rows [][]Wall
-	for i, row := range fileContents {
-		var cols []Wall
-		for j, col := range row {
-			curLetter := fmt.Sprintf("%c", col)
-
-			var wall Wall
-			wall.State.Row = i
-			wall.State.Col = j
-
-			switch curLetter {
-			case "A":
-				g.Start = Point{Row: i, Col: j}
-				wall.wall = false
-			case "B":
-				g.Goal = Point{Row: i, Col: j}
-				wall.wall = false
-			case " ":
-				wall.wall = false
-			case "w":
-				wall.wall = false
-				wall.State.Water = true
-			case "#":
-				wall.wall = true
-			default:
-				continue
-			}
-
-			cols = append(cols, wall)
-		}
-		rows = append(rows, cols)
+	if seed == 0 {
+		seed = time.Now().UnixNano()
 	}
-
-	g.Walls = rows
-	return nil
-}
-
-func (g *Maze) inSolution(x Point) bool {
-	for _, step := range g.Solution.Cells {
-		if step.Row == x.Row && step.Col == x.Col {
-			return true
+	m.SetSeed(seed)
+	m.Progress = printProgress
+
+	// the maze file may choose a movement model, unless one is given here
+	var err error
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "movement" {
+			m.Movement, err = maze.LookupMovement(movement)
 		}
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
-	return false
-}
 
-func (g *Maze) PrintMaze() {
-	for r, row := range g.Walls {
-		for c, col := range row {
-			if col.wall {
-				fmt.Print("◼")
-			} else if g.Start.Row == col.State.Row && g.Start.Col == col.State.Col {
-				fmt.Print("A")
-			} else if g.Goal.Row == col.State.Row && g.Goal.Col == col.State.Col {
-				fmt.Print("B")
-			} else if g.inSolution(Point{r, c, false}) {
-				fmt.Print("*")
-			} else {
-				fmt.Print(" ")
-			}
-		}
-		fmt.Println()
+	if animate {
+		m.Animation = animator
 	}
-}
-
-func init() {
-	_ = os.Mkdir("./tmp", os.ModePerm)
-	emptyTmp()
-}
+	m.KeepTree = treeFile != ""
 
-func main() {
-	var m Maze
-	var maze, searchType string
-
-	flag.StringVar(&maze, "file", "maze.txt", "maze file")
-	flag.StringVar(&searchType, "search", "dfs", "search type")
-	flag.BoolVar(&m.Debug, "debug", false, "write debugging info")
-	flag.BoolVar(&m.Animate, "animate", false, "produce animation")
-	flag.Parse()
+	err = m.Load(mazeFile)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 
-	err := m.Load(maze)
+	strategy, err := maze.LookupStrategy(searchType)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	startTime := time.Now()
+	var order []string
+	if waypoints != "" {
+		order = strings.Split(waypoints, ",")
+	}
+
+	// interrupting a long search stops it with the best path found so far
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
-	switch searchType {
-	case "dfs":
-		m.SearchType = DFS
-		solveDFS(&m)
-	case "bfs":
-		m.SearchType = BFS
-		solveBFS(&m)
-	case "dijkstra":
-		m.SearchType = DIJKSTRA
-		solveDijkstra(&m)
-	case "gbfs":
-		m.SearchType = GBFS
-		solveGBFS(&m)
-	case "astar":
-		m.SearchType = ASTAR
-		solveAStar(&m)
-	default:
-		fmt.Println("invalid search type")
+	result, err := solve(ctx, &m, strategy, route, order)
+	if err != nil {
+		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	if len(m.Solution.Actions) > 0 {
+	if result.Status.Stopped() {
+		fmt.Println("search stopped:", result.Status)
+		fmt.Printf("closest cell reached is %v, %s from the goal by the heuristic\n",
+			result.Closest, maze.FormatCost(m.Heuristic(result.Closest)))
+		fmt.Println("partial path is", len(result.Solution.Cells), "steps")
+		fmt.Println("partial path cost is", result.Solution.Cost)
+		fmt.Println("time to stop:", result.Elapsed)
+		if outfile != "" {
+			if err := outputImage(&m, outfile); err != nil {
+				fmt.Println(err)
+			}
+		}
+	} else if len(result.Solution.Actions) > 0 {
 		fmt.Println("solution:")
-		// m.PrintMaze()
-		fmt.Println("solution is", len(m.Solution.Cells), "steps")
-		fmt.Println("time to solve:", time.Since(startTime))
-		m.OutputImage("image.png")
+		fmt.Println("solution is", len(result.Solution.Cells), "steps")
+		fmt.Println("solution cost is", result.Solution.Cost)
+		if len(m.Goals) > 1 {
+			for i, leg := range result.Solution.Legs {
+				fmt.Printf("  leg %d: %v to %s (%v), %d steps, cost %s\n",
+					i+1, leg.From, m.GoalLabels[leg.To], leg.To, leg.Steps, maze.FormatCost(leg.Cost))
+			}
+		}
+		fmt.Println("time to solve:", result.Elapsed)
+		if outfile != "" {
+			if err := outputImage(&m, outfile); err != nil {
+				fmt.Println(err)
+			}
+		}
+		if solutionFile != "" {
+			if err := saveSolution(&m, result, solutionFile); err != nil {
+				fmt.Println(err)
+			} else {
+				fmt.Println("wrote solution to", solutionFile)
+			}
+		}
 	} else {
 		fmt.Println("no solution")
 	}
 
-	fmt.Println("explored", len(m.Explored), "nodes")
+	fmt.Println("explored", len(result.Explored), "nodes")
 
-	if m.Animate {
+	if treeFile != "" {
+		if err := export(treeFile, m.SearchTree(result.Search)); err != nil {
+			fmt.Println(err)
+		} else {
+			fmt.Println("wrote search tree to", treeFile)
+		}
+	}
+	if graphFile != "" {
+		if err := export(graphFile, m.Graph()); err != nil {
+			fmt.Println(err)
+		} else {
+			fmt.Println("wrote maze graph to", graphFile)
+		}
+	}
+
+	if m.Animation != nil {
 		fmt.Println("building animation...")
-		m.OutputAnimatedImage()
-		fmt.Println("done!")
+		m.Animation.Finish(&m)
+		if err := m.Animation.Write(animationFile); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("wrote %d frames to %s\n", m.Animation.Frames(), animationFile)
 	}
 }
 
-func solveDFS(m *Maze) {
-	var s DepthFirstSearch
-	s.Game = m
-	fmt.Println("goal is", s.Game.Goal)
-	s.Solve()
+func solve(ctx context.Context, m *maze.Maze, strategy maze.Strategy, route string, order []string) (maze.Result, error) {
+	if m.Name != "" {
+		fmt.Println("maze is", m.Name)
+	}
+	fmt.Println("goal is", m.Goal)
+	if len(m.Goals) > 1 {
+		fmt.Println("goals are", m.Goals)
+	}
+	fmt.Println("seed is", m.Seed())
+	return maze.SolveRoute(ctx, m, strategy, route, order)
 }
 
-func solveBFS(m *Maze) {
-	var s BreadthFirstSearch
-	s.Game = m
-	fmt.Println("goal is", s.Game.Goal)
-	s.Solve()
+// saveSolution writes a solution to a file in the JSON form verify reads.
+func saveSolution(m *maze.Maze, result maze.Result, filename string) error {
+	data, err := json.MarshalIndent(m.SolutionFile(result.Solution, result.Search), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(data, '\n'), 0644)
 }
 
-func solveDijkstra(m *Maze) {
-	var s DijkstraSearch
-	s.Game = m
-	fmt.Println("goal is", s.Game.Goal)
-	s.Solve()
+// export writes a search tree or maze graph to a file, as JSON if its name
+// ends in .json and in Graphviz's DOT language otherwise.
+func export(filename string, v interface{ WriteDOT(io.Writer) error }) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if strings.HasSuffix(filename, ".json") {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	return v.WriteDOT(f)
 }
 
-func solveGBFS(m *Maze) {
-	var s GreedyBestFirstSearch
-	s.Game = m
-	fmt.Println("goal is", s.Game.Goal)
-	s.Solve()
+// addLimitFlags registers the flags that limit how much work a search may
+// do.
+func addLimitFlags(fs *flag.FlagSet, l *maze.Limits) {
+	fs.IntVar(&l.MaxExpanded, "max-expanded", 0, "stop a search after expanding this many cells (0 for no limit)")
+	fs.DurationVar(&l.Timeout, "timeout", 0, "stop searching after this long, e.g. 500ms (0 for no limit)")
 }
 
-func solveAStar(m *Maze) {
-	var s AStarSearch
-	s.Game = m
-	fmt.Println("goal is", s.Game.Goal)
-	s.Solve()
+// printProgress prints what a solver reports as it works. Expanding a cell
+// happens too often to be worth printing.
+func printProgress(p maze.Progress) {
+	switch p.Kind {
+	case maze.Started:
+		fmt.Printf("starting to solve maze using %s...\n", p.Message)
+	case maze.Notice, maze.Detail:
+		fmt.Println(p.Message)
+	}
 }
@@ -0,0 +1,184 @@
+package maze
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kmicki/apng"
+)
+
+// Animator records the progress of a search as animation frames held in
+// memory. The first frame is the whole maze; every frame after that only
+// holds the rectangle of cells that changed since the previous frame, so
+// recording costs little more than the cells a solver actually touches.
+type Animator struct {
+	Delay      int // delay after each frame, in hundredths of a second
+	FinalDelay int // delay on the finished maze, in hundredths of a second
+	Skip       int // only keep every Skip'th frame
+
+	canvas *image.RGBA
+	frames []animationFrame
+	dirty  map[Point]bool
+	calls  int
+}
+
+type animationFrame struct {
+	image  *image.RGBA
+	offset image.Point
+	delay  int
+}
+
+// NewAnimator returns an animator with the delays used by default.
+func NewAnimator() *Animator {
+	return &Animator{
+		Delay:      15,
+		FinalDelay: 200,
+		Skip:       1,
+	}
+}
+
+// MarkDirty notes that the cell at p needs to be redrawn in the next frame.
+func (a *Animator) MarkDirty(p Point) {
+	if a.dirty == nil {
+		a.dirty = make(map[Point]bool)
+	}
+	a.dirty[p] = true
+}
+
+// Capture records the current state of the maze as a frame, unless it is
+// being skipped.
+func (a *Animator) Capture(g *Maze) {
+	a.calls++
+	if a.Skip > 1 && a.calls%a.Skip != 0 {
+		return
+	}
+	a.capture(g, a.Delay)
+}
+
+// Finish records the final state of the maze, which is always kept and is
+// shown for FinalDelay.
+func (a *Animator) Finish(g *Maze) {
+	// the solution may touch any cell, so redraw everything
+	a.canvas = nil
+	a.capture(g, a.FinalDelay)
+}
+
+func (a *Animator) capture(g *Maze, delay int) {
+	if a.canvas == nil {
+		a.canvas = g.render()
+		a.dirty = nil
+		a.frames = append(a.frames, animationFrame{image: cloneRGBA(a.canvas, a.canvas.Bounds()), delay: delay})
+		return
+	}
+
+	if len(a.dirty) == 0 {
+		// nothing changed, so just show the previous frame for longer
+		a.frames[len(a.frames)-1].delay += delay
+		return
+	}
+
+	var changed image.Rectangle
+	for p := range a.dirty {
+		g.drawCell(a.canvas, p)
+		changed = changed.Union(g.cellBounds(p))
+	}
+	a.dirty = nil
+
+	changed = changed.Intersect(a.canvas.Bounds())
+	a.frames = append(a.frames, animationFrame{
+		image:  cloneRGBA(a.canvas, changed),
+		offset: changed.Min,
+		delay:  delay,
+	})
+}
+
+// Frames returns the number of frames recorded so far.
+func (a *Animator) Frames() int {
+	return len(a.frames)
+}
+
+// Write encodes the animation to filename, as an animated GIF if the name
+// ends in .gif and as an animated PNG otherwise.
+func (a *Animator) Write(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(filename), ".gif") {
+		return a.EncodeGIF(f)
+	}
+	return a.EncodeAPNG(f)
+}
+
+// EncodeAPNG writes the animation as an animated PNG.
+func (a *Animator) EncodeAPNG(w io.Writer) error {
+	if len(a.frames) == 0 {
+		return errors.New("no frames to encode")
+	}
+
+	out := apng.APNG{
+		Frames: make([]apng.Frame, len(a.frames)),
+	}
+
+	for i, frame := range a.frames {
+		out.Frames[i] = apng.Frame{
+			Image:          frame.image,
+			XOffset:        frame.offset.X,
+			YOffset:        frame.offset.Y,
+			DelayNumerator: uint16(frame.delay),
+			DisposeOp:      apng.DISPOSE_OP_NONE,
+			BlendOp:        apng.BLEND_OP_SOURCE,
+		}
+	}
+
+	return apng.Encode(w, out)
+}
+
+// gifPalette holds every colour the maze is drawn with.
+var gifPalette = color.Palette{
+	color.Black, color.White, green, darkGreen, red, yellow, gray, orange, blue,
+}
+
+// EncodeGIF writes the animation as an animated GIF.
+func (a *Animator) EncodeGIF(w io.Writer) error {
+	if len(a.frames) == 0 {
+		return errors.New("no frames to encode")
+	}
+
+	full := a.frames[0].image.Bounds()
+	out := gif.GIF{
+		Config: image.Config{
+			ColorModel: gifPalette,
+			Width:      full.Dx(),
+			Height:     full.Dy(),
+		},
+	}
+
+	for _, frame := range a.frames {
+		bounds := frame.image.Bounds().Add(frame.offset)
+		img := image.NewPaletted(bounds, gifPalette)
+		draw.Draw(img, bounds, frame.image, image.Point{}, draw.Src)
+
+		out.Image = append(out.Image, img)
+		out.Delay = append(out.Delay, frame.delay)
+		out.Disposal = append(out.Disposal, gif.DisposalNone)
+	}
+
+	return gif.EncodeAll(w, &out)
+}
+
+// cloneRGBA copies part of an image into a new image with its origin at 0,0.
+func cloneRGBA(src *image.RGBA, r image.Rectangle) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, r.Min, draw.Src)
+	return dst
+}
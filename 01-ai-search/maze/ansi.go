@@ -0,0 +1,71 @@
+package maze
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ansiRenderer draws the maze on a terminal, using 24-bit colour escape
+// codes for the same colours as the image renderers. Each cell is two
+// characters wide, or five when an overlay needs room for a number.
+type ansiRenderer struct{}
+
+func (ansiRenderer) Render(w io.Writer, g *Maze) error {
+	bw := bufio.NewWriter(w)
+
+	width := 2
+	if g.View.Order || g.View.Costs {
+		width = 5
+	}
+
+	for i, row := range g.Walls {
+		// odd rows of a hex grid are shifted by half a cell
+		if g.movement().Hex && i%2 == 1 {
+			bw.WriteString(strings.Repeat(" ", width/2))
+		}
+
+		for j := range row {
+			p := Point{Row: i, Col: j}
+			c := g.cellColour(p)
+			text := g.ansiText(p)
+			if len(text) > width {
+				text = text[:width]
+			}
+			fmt.Fprintf(bw, "\x1b[48;2;%d;%d;%dm\x1b[30m%-*s", c.R, c.G, c.B, width, text)
+		}
+		bw.WriteString("\x1b[0m\n")
+	}
+
+	return bw.Flush()
+}
+
+// ansiText is what to write inside the cell at p on a terminal.
+func (g *Maze) ansiText(p Point) string {
+	col := g.Walls[p.Row][p.Col]
+	switch {
+	case col.wall:
+		return ""
+	case p == g.Start:
+		return "A"
+	}
+	if label, ok := g.GoalLabels[p]; ok {
+		return label
+	}
+
+	if g.View.Order {
+		if i := g.exploredOrder(p); i > 0 {
+			return fmt.Sprint(i)
+		}
+	}
+	if g.View.Costs {
+		if cost, ok := g.PathCosts[p]; ok {
+			return FormatCost(cost + g.Heuristic(p))
+		}
+	}
+	if col.Terrain.Symbol != ' ' {
+		return string(col.Terrain.Symbol)
+	}
+	return ""
+}
@@ -0,0 +1,16 @@
+package maze
+
+// newAStarFrontier expands the node with the lowest estimated total cost,
+// f(n) = g(n) + h(n), where g is the path cost from the start and h is the
+// movement model's heuristic (Manhattan, octile or hex distance). Every step
+// costs at least its length, so h never overestimates and the solution
+// found is optimal.
+func newAStarFrontier(m *Maze) Frontier {
+	return &PriorityFrontier{
+		Cost: func(n *Node) float64 {
+			n.CostToGoal = m.Heuristic(n.State)
+			n.EstimatedCostToGoal = n.PathCost + n.CostToGoal
+			return n.EstimatedCostToGoal
+		},
+	}
+}
@@ -0,0 +1,7 @@
+package maze
+
+// newBreadthFirstFrontier expands nodes in the order they were discovered,
+// so every node at depth n is explored before any node at depth n+1.
+func newBreadthFirstFrontier(_ *Maze) Frontier {
+	return &QueueFrontier{}
+}
@@ -0,0 +1,144 @@
+package maze
+
+import (
+	"context"
+	"slices"
+)
+
+// BidirectionalSearch runs two breadth first searches at once, one forward
+// from the start and one backward from the goal, and stops when they meet.
+// Each side only has to search about half the depth of a single BFS, so it
+// explores far fewer nodes on large mazes.
+type BidirectionalSearch struct {
+	Game *Maze
+}
+
+func (bs *BidirectionalSearch) Solve(ctx context.Context) error {
+	bs.Game.reportf(Started, "bidirectional breadth first search")
+
+	bs.Game.resetSearch()
+
+	start := &Node{State: bs.Game.Start}
+	bs.Game.CurrentNode = start
+
+	if bs.Game.isGoal(start.State) {
+		err := bs.Game.visit(ctx, start)
+		bs.Game.setSolution(Solution{})
+		return err
+	}
+
+	forward := map[Point]*Node{start.State: start}
+	forwardLevel := []*Node{start}
+
+	// with several goals, the backward search starts from all of them at
+	// once and meets the forward search at whichever is nearest
+	backward := make(map[Point]*Node)
+	var backwardLevel []*Node
+	for _, p := range bs.Game.goals() {
+		goal := &Node{State: p}
+		backward[p] = goal
+		backwardLevel = append(backwardLevel, goal)
+	}
+
+	for len(forwardLevel) > 0 && len(backwardLevel) > 0 {
+		var meet *Point
+		var err error
+
+		// always grow the smaller side, which keeps the two searches balanced
+		if len(forwardLevel) <= len(backwardLevel) {
+			forwardLevel, meet, err = bs.expandLevel(ctx, forwardLevel, forward, backward)
+		} else {
+			backwardLevel, meet, err = bs.expandLevel(ctx, backwardLevel, backward, forward)
+		}
+
+		if err != nil {
+			// only the forward side has paths from the start, so the closest
+			// cell has to come from there
+			bs.Game.closest = nil
+			for _, n := range forward {
+				bs.Game.consider(n)
+			}
+			return bs.Game.giveUp(err)
+		}
+
+		bs.Game.trackFrontier(len(forwardLevel) + len(backwardLevel))
+
+		if meet != nil {
+			bs.Game.recordFrontier(append(slices.Clone(forwardLevel), backwardLevel...))
+			bs.Game.setSolution(bs.join(forward[*meet], backward[*meet]))
+			return nil
+		}
+	}
+	return nil
+}
+
+// expandLevel expands every node in one level of a search and returns the
+// next level. If the search has reached a node seen by the other side, it
+// also returns the meeting point on the shortest joined path.
+func (bs *BidirectionalSearch) expandLevel(ctx context.Context, level []*Node, seen, other map[Point]*Node) ([]*Node, *Point, error) {
+	var next []*Node
+	var meet *Point
+	best := 0
+
+	for _, n := range level {
+		if err := bs.Game.visit(ctx, n); err != nil {
+			return next, nil, err
+		}
+		bs.Game.frame()
+
+		for _, x := range bs.Game.Neighbors(n) {
+			if _, ok := seen[x.State]; ok {
+				continue
+			}
+
+			child := &Node{
+				State:    x.State,
+				Parent:   n,
+				Action:   x.Action,
+				PathCost: x.PathCost,
+			}
+			seen[x.State] = child
+			next = append(next, child)
+
+			// finish the whole level before stopping, since a later node in
+			// this level may join the other side by a shorter route
+			if o, ok := other[x.State]; ok {
+				if length := depth(child) + depth(o); meet == nil || length < best {
+					state := x.State
+					meet, best = &state, length
+				}
+			}
+		}
+	}
+
+	return next, meet, nil
+}
+
+// join combines the forward path to the meeting point with the reversed
+// backward path from the meeting point to the goal.
+func (bs *BidirectionalSearch) join(forward, backward *Node) Solution {
+	solution := solutionFrom(forward)
+
+	for n := backward; n.Parent != nil; n = n.Parent {
+		solution.Actions = append(solution.Actions, opposites[n.Action])
+		solution.Cells = append(solution.Cells, n.Parent.State)
+	}
+
+	solution.Cost = 0
+	from := bs.Game.Start
+	for _, p := range solution.Cells {
+		solution.Cost += bs.Game.moveCost(from, p)
+		from = p
+	}
+
+	return solution
+}
+
+// depth returns the number of moves from the root of the search tree to n.
+func depth(n *Node) int {
+	d := 0
+	for ; n.Parent != nil; n = n.Parent {
+		d++
+	}
+	return d
+}
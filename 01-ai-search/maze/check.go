@@ -0,0 +1,121 @@
+package maze
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Check makes sure a solution is a legal path through the maze: each step is
+// a move the movement model allows, into an open cell, labelled with that
+// move's action; the path visits the goals as its route says; and its cost
+// is what its steps add up to.
+//
+// A waypoints route must pass its waypoints in order, ending at the last; a
+// nearest route must end at any goal; and any other route must pass every
+// goal, ending at B if the maze has one.
+func (g *Maze) Check(s Solution) error {
+	if s.Actions != nil && len(s.Actions) != len(s.Cells) {
+		return fmt.Errorf("solution has %d actions but %d cells", len(s.Actions), len(s.Cells))
+	}
+
+	goals := g.Goals
+	if len(goals) == 0 {
+		goals = []Point{g.Goal}
+	}
+	stops := s.Waypoints
+	if len(stops) == 0 {
+		stops = goals
+	}
+
+	unvisited := make(map[Point]bool)
+	for _, goal := range goals {
+		unvisited[goal] = true
+	}
+	delete(unvisited, g.Start)
+
+	// next is the waypoint to reach next, for a waypoints route
+	next := 0
+	if stops[0] == g.Start {
+		next++
+	}
+
+	from, cost := g.Start, 0.0
+	for i, to := range s.Cells {
+		move, ok := g.moveBetween(from, to)
+		if !ok {
+			return fmt.Errorf("step %d: can't move from %v to %v", i+1, from, to)
+		}
+		if s.Actions != nil && s.Actions[i] != move.Action {
+			return fmt.Errorf("step %d: moving from %v to %v is %s, not %s", i+1, from, to, move.Action, s.Actions[i])
+		}
+
+		cost += g.StepCost(to) * move.Length
+		delete(unvisited, to)
+		if next < len(stops) && to == stops[next] {
+			next++
+		}
+		from = to
+	}
+
+	switch s.Route {
+	case "waypoints":
+		if next < len(stops) {
+			return fmt.Errorf("solution misses waypoint %s %v, or passes it out of order", g.GoalLabels[stops[next]], stops[next])
+		}
+		if last := stops[len(stops)-1]; from != last {
+			return fmt.Errorf("solution ends at %v, not the last waypoint %s %v", from, g.GoalLabels[last], last)
+		}
+	case "nearest":
+		if !slices.Contains(goals, from) {
+			return fmt.Errorf("solution ends at %v, which is not a goal", from)
+		}
+	default:
+		if err := g.checkTour(from, goals, unvisited); err != nil {
+			return err
+		}
+	}
+
+	if math.Abs(cost-s.Cost) > 1e-9*max(1, cost) {
+		return fmt.Errorf("solution says it costs %v, but its steps cost %v", s.Cost, cost)
+	}
+	return nil
+}
+
+// checkTour makes sure a path ending at end visited every goal, leaving none
+// unvisited, and ends at B if the maze has one or at some goal otherwise.
+func (g *Maze) checkTour(end Point, goals []Point, unvisited map[Point]bool) error {
+	if len(unvisited) > 0 {
+		var missed []string
+		for _, goal := range goals {
+			if unvisited[goal] {
+				missed = append(missed, fmt.Sprintf("%s %v", g.GoalLabels[goal], goal))
+			}
+		}
+		slices.Sort(missed)
+		return fmt.Errorf("solution misses goals %v", missed)
+	}
+
+	if g.GoalLabels[g.Goal] == "B" || len(goals) == 1 {
+		if end != g.Goal {
+			return fmt.Errorf("solution ends at %v, not the goal %v", end, g.Goal)
+		}
+	} else if !slices.Contains(goals, end) {
+		return fmt.Errorf("solution ends at %v, which is not a goal", end)
+	}
+	return nil
+}
+
+// moveBetween returns the move that takes an agent from one cell to a
+// neighbouring one, if there is a legal one.
+func (g *Maze) moveBetween(from, to Point) (Move, bool) {
+	if !g.open(from) {
+		return Move{}, false
+	}
+	for _, move := range g.movement().Moves(from) {
+		if from.Row+move.Row == to.Row && from.Col+move.Col == to.Col && g.allowed(from, move) {
+			return move, true
+		}
+	}
+	return Move{}, false
+}
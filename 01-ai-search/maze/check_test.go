@@ -0,0 +1,119 @@
+package maze
+
+import (
+	"strings"
+	"testing"
+)
+
+// goalsMaze has three goals and a patch of mud. Visiting them in label
+// order, along the top and round the right-hand side to B, costs 8.
+const goalsMaze = `maze v2
+terrain: ~ mud 3
+---
+#######
+#A 1 2#
+# ### #
+#~ B  #
+#######
+`
+
+// cells turns row, col pairs into points.
+func cells(pairs ...[2]int) []Point {
+	var ps []Point
+	for _, p := range pairs {
+		ps = append(ps, Point{Row: p[0], Col: p[1]})
+	}
+	return ps
+}
+
+// TestCheck checks solutions that are legal, and ones that break each of
+// the rules Check enforces.
+func TestCheck(t *testing.T) {
+	m := readMaze(t, goalsMaze)
+	waypoints := cells([2]int{1, 3}, [2]int{1, 5}, [2]int{3, 3})
+	inOrder := cells([2]int{1, 2}, [2]int{1, 3}, [2]int{1, 4}, [2]int{1, 5}, [2]int{2, 5}, [2]int{3, 5}, [2]int{3, 4}, [2]int{3, 3})
+	throughMud := cells([2]int{2, 1}, [2]int{3, 1}, [2]int{3, 2}, [2]int{3, 3})
+
+	tests := []struct {
+		name     string
+		solution Solution
+		wantErr  string
+	}{
+		{
+			name:     "waypoints",
+			solution: Solution{Cells: inOrder, Cost: 8, Route: "waypoints", Waypoints: waypoints},
+		},
+		{
+			name:     "every goal",
+			solution: Solution{Cells: inOrder, Cost: 8},
+		},
+		{
+			name:     "nearest",
+			solution: Solution{Actions: []string{"right", "right"}, Cells: inOrder[:2], Cost: 2, Route: "nearest"},
+		},
+		{
+			name:     "into a wall",
+			solution: Solution{Cells: cells([2]int{1, 2}, [2]int{2, 2}), Cost: 2},
+			wantErr:  "step 2: can't move from {1 2} to {2 2}",
+		},
+		{
+			name:     "gap",
+			solution: Solution{Cells: cells([2]int{1, 2}, [2]int{1, 4}), Cost: 2},
+			wantErr:  "step 2: can't move from {1 2} to {1 4}",
+		},
+		{
+			name:     "wrong action",
+			solution: Solution{Actions: []string{"right", "up"}, Cells: inOrder[:2], Cost: 2, Route: "nearest"},
+			wantErr:  "step 2: moving from {1 2} to {1 3} is right, not up",
+		},
+		{
+			name:     "actions and cells disagree",
+			solution: Solution{Actions: []string{"right"}, Cells: inOrder[:2], Cost: 2},
+			wantErr:  "1 actions but 2 cells",
+		},
+		{
+			name:     "missed waypoint",
+			solution: Solution{Cells: throughMud, Cost: 6, Route: "waypoints", Waypoints: waypoints},
+			wantErr:  "misses waypoint 1 {1 3}",
+		},
+		{
+			name:     "waypoints out of order",
+			solution: Solution{Cells: inOrder, Cost: 8, Route: "waypoints", Waypoints: cells([2]int{1, 5}, [2]int{1, 3}, [2]int{3, 3})},
+			wantErr:  "misses waypoint 1 {1 3}",
+		},
+		{
+			name:     "short of the last waypoint",
+			solution: Solution{Cells: inOrder[:7], Cost: 7, Route: "waypoints", Waypoints: waypoints[:2]},
+			wantErr:  "not the last waypoint 2 {1 5}",
+		},
+		{
+			name:     "nearest short of a goal",
+			solution: Solution{Cells: inOrder[:1], Cost: 1, Route: "nearest"},
+			wantErr:  "{1 2}, which is not a goal",
+		},
+		{
+			name:     "missed goals",
+			solution: Solution{Cells: throughMud, Cost: 6},
+			wantErr:  "misses goals [1 {1 3} 2 {1 5}]",
+		},
+		{
+			name:     "cost mismatch",
+			solution: Solution{Cells: throughMud, Cost: 4, Route: "nearest"},
+			wantErr:  "says it costs 4, but its steps cost 6",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.Check(tt.solution)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Fatalf("solution passed, want an error mentioning %q", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Fatalf("error %q does not mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package maze
+
+// newDepthFirstFrontier always expands the most recently discovered node,
+// diving as deep as possible before backtracking.
+func newDepthFirstFrontier(_ *Maze) Frontier {
+	return &StackFrontier{}
+}
@@ -0,0 +1,11 @@
+package maze
+
+// newDijkstraFrontier expands the node with the lowest path cost from the
+// start, which makes it optimal on mazes with weighted terrain.
+func newDijkstraFrontier(_ *Maze) Frontier {
+	return &PriorityFrontier{
+		Cost: func(n *Node) float64 {
+			return n.PathCost
+		},
+	}
+}
@@ -0,0 +1,319 @@
+package maze
+
+import (
+	"container/heap"
+	"context"
+	"math"
+)
+
+// DStarLite is the D* Lite algorithm of Koenig and Likhachev. It searches
+// backwards from the goal, keeping for every cell g, its cost to the goal,
+// and rhs, a one step lookahead of g. A cell whose g and rhs disagree is
+// inconsistent and waits on the queue to be expanded.
+//
+// Once a plan has been made the agent can move along it, and when cells of
+// the maze change only the costs they affect are repaired, so replanning
+// from wherever the agent has got to is much cheaper than searching again.
+type DStarLite struct {
+	Game *Maze
+
+	start Point   // where the agent is now
+	last  Point   // where the agent was when km was last brought up to date
+	km    float64 // how far the heuristic has drifted as the agent moved
+	g     []float64
+	rhs   []float64
+	queue dstarQueue
+	entry []*dstarEntry
+}
+
+func newDStarLite(m *Maze) Solver {
+	return &DStarLite{Game: m}
+}
+
+// Solve plans a path from the start to the goal, as any other solver. D*
+// Lite searches back from the goal, so if it is stopped early it has no
+// partial path from the start to offer.
+func (d *DStarLite) Solve(ctx context.Context) error {
+	d.Game.reportf(Started, "D* Lite")
+
+	d.Game.resetSearch()
+	d.Init(d.Game.Start)
+	if _, err := d.Plan(ctx); err != nil {
+		d.Game.closest = nil
+		return d.Game.giveUp(err)
+	}
+
+	if d.Reachable() {
+		d.Game.setSolution(d.Path())
+	}
+	return nil
+}
+
+// Init clears any previous plan and puts the agent at start.
+func (d *DStarLite) Init(start Point) {
+	n := d.Game.Height * d.Game.Width
+	d.start, d.last, d.km = start, start, 0
+	d.g = make([]float64, n)
+	d.rhs = make([]float64, n)
+	for i := range n {
+		d.g[i], d.rhs[i] = math.Inf(1), math.Inf(1)
+	}
+	d.queue = nil
+	d.entry = make([]*dstarEntry, n)
+
+	for _, goal := range d.Game.goals() {
+		d.rhs[d.cell(goal)] = 0
+		d.update(goal)
+	}
+}
+
+// Plan expands inconsistent cells until the agent's cost to the goal is
+// known, returning how many cells it expanded. If it is stopped early the
+// plan can be carried on by calling Plan again.
+func (d *DStarLite) Plan(ctx context.Context) (int, error) {
+	expanded := 0
+	for len(d.queue) > 0 {
+		top := d.queue[0]
+		u, i := top.p, d.cell(top.p)
+		if !top.key.less(d.key(d.start)) && d.rhs[d.cell(d.start)] <= d.g[d.cell(d.start)] {
+			break
+		}
+
+		if k := d.key(u); top.key.less(k) {
+			// the key is out of date since the agent moved
+			top.key = k
+			heap.Fix(&d.queue, top.index)
+			continue
+		}
+
+		if err := d.Game.limit(ctx); err != nil {
+			return expanded, err
+		}
+
+		expanded++
+		if d.g[i] > d.rhs[i] {
+			// overconsistent: its cost has come down, so settle it and pass
+			// the saving on
+			d.g[i] = d.rhs[i]
+			d.remove(u)
+			d.predecessors(u, func(s Point, cost float64) {
+				if j := d.cell(s); d.rhs[j] > cost+d.g[i] && !d.isGoal(s) {
+					d.rhs[j] = cost + d.g[i]
+					d.update(s)
+				}
+			})
+		} else {
+			// underconsistent: its cost has gone up, so everything that
+			// relied on it has to look again
+			d.g[i] = math.Inf(1)
+			d.repair(u)
+			d.predecessors(u, func(s Point, _ float64) {
+				d.repair(s)
+			})
+		}
+
+		d.Game.expand(&Node{State: u, PathCost: d.rhs[i]})
+		if math.IsInf(d.rhs[i], 1) {
+			delete(d.Game.PathCosts, u)
+		}
+		d.Game.frame()
+		d.Game.trackFrontier(len(d.queue))
+	}
+
+	waiting := make([]*Node, len(d.queue))
+	for i, e := range d.queue {
+		waiting[i] = &Node{State: e.p}
+	}
+	d.Game.recordFrontier(waiting)
+
+	return expanded, nil
+}
+
+// MoveTo records that the agent has moved to p.
+func (d *DStarLite) MoveTo(p Point) {
+	d.start = p
+}
+
+// Changed repairs the plan after the cells given have changed, and must be
+// followed by Plan. Changing a cell can alter the cost of any move into or
+// out of it, and of diagonal moves past its corners, so every cell next to
+// it is looked at again.
+func (d *DStarLite) Changed(cells []Point) {
+	d.km += d.Game.movement().Heuristic(d.last, d.start)
+	d.last = d.start
+
+	for _, c := range cells {
+		for dr := -1; dr <= 1; dr++ {
+			for dc := -1; dc <= 1; dc++ {
+				p := Point{Row: c.Row + dr, Col: c.Col + dc}
+				if p.Row >= 0 && p.Row < d.Game.Height && p.Col >= 0 && p.Col < d.Game.Width {
+					d.repair(p)
+				}
+			}
+		}
+	}
+}
+
+// Reachable reports whether the agent can still get to the goal.
+func (d *DStarLite) Reachable() bool {
+	return !math.IsInf(d.rhs[d.cell(d.start)], 1)
+}
+
+// Next returns the best move to make from p, and the cell it leads to.
+func (d *DStarLite) Next(p Point) (Move, Point, bool) {
+	best, found := math.Inf(1), false
+	var next Move
+	d.successors(p, func(move Move, s Point, cost float64) {
+		if c := cost + d.g[d.cell(s)]; c < best {
+			best, next, found = c, move, true
+		}
+	})
+	return next, Point{Row: p.Row + next.Row, Col: p.Col + next.Col}, found
+}
+
+// Path follows the plan from the agent to the goal.
+func (d *DStarLite) Path() Solution {
+	var solution Solution
+	p := d.start
+	for !d.isGoal(p) && len(solution.Cells) < len(d.g) {
+		move, next, ok := d.Next(p)
+		if !ok {
+			return Solution{}
+		}
+		solution.Actions = append(solution.Actions, move.Action)
+		solution.Cells = append(solution.Cells, next)
+		solution.Cost += d.Game.StepCost(next) * move.Length
+		p = next
+	}
+	return solution
+}
+
+func (d *DStarLite) cell(p Point) int {
+	return p.Row*d.Game.Width + p.Col
+}
+
+func (d *DStarLite) isGoal(p Point) bool {
+	return d.Game.isGoal(p)
+}
+
+// key orders the queue: cells on a cheap path near the agent come first.
+func (d *DStarLite) key(p Point) dstarKey {
+	i := d.cell(p)
+	m := min(d.g[i], d.rhs[i])
+	return dstarKey{m + d.Game.movement().Heuristic(d.start, p) + d.km, m}
+}
+
+// repair works out rhs for p again from its successors, and requeues it if
+// that leaves it inconsistent.
+func (d *DStarLite) repair(p Point) {
+	if d.isGoal(p) {
+		return
+	}
+
+	best := math.Inf(1)
+	if d.Game.open(p) {
+		d.successors(p, func(_ Move, s Point, cost float64) {
+			best = min(best, cost+d.g[d.cell(s)])
+		})
+	}
+	d.rhs[d.cell(p)] = best
+	d.update(p)
+}
+
+// update puts p on the queue if it is inconsistent, and takes it off if not.
+func (d *DStarLite) update(p Point) {
+	i := d.cell(p)
+	switch e := d.entry[i]; {
+	case d.g[i] != d.rhs[i] && e != nil:
+		e.key = d.key(p)
+		heap.Fix(&d.queue, e.index)
+	case d.g[i] != d.rhs[i]:
+		e = &dstarEntry{p: p, key: d.key(p)}
+		d.entry[i] = e
+		heap.Push(&d.queue, e)
+	case e != nil:
+		d.remove(p)
+	}
+}
+
+func (d *DStarLite) remove(p Point) {
+	i := d.cell(p)
+	if e := d.entry[i]; e != nil {
+		heap.Remove(&d.queue, e.index)
+		d.entry[i] = nil
+	}
+}
+
+// successors calls fn for every cell reachable from p in one move.
+func (d *DStarLite) successors(p Point, fn func(move Move, s Point, cost float64)) {
+	for _, move := range d.Game.movement().Moves(p) {
+		if d.Game.allowed(p, move) {
+			s := Point{Row: p.Row + move.Row, Col: p.Col + move.Col}
+			fn(move, s, d.Game.StepCost(s)*move.Length)
+		}
+	}
+}
+
+// predecessors calls fn for every cell that can reach p in one move. Moves
+// on a hex grid depend on the row, so this checks the moves from each
+// neighbour rather than assuming they mirror the moves from p.
+func (d *DStarLite) predecessors(p Point, fn func(s Point, cost float64)) {
+	for _, move := range d.Game.movement().Moves(p) {
+		s := Point{Row: p.Row + move.Row, Col: p.Col + move.Col}
+		if !d.Game.open(s) {
+			continue
+		}
+		for _, back := range d.Game.movement().Moves(s) {
+			if s.Row+back.Row == p.Row && s.Col+back.Col == p.Col && d.Game.allowed(s, back) {
+				fn(s, d.Game.StepCost(p)*back.Length)
+			}
+		}
+	}
+}
+
+// dstarKey is a D* Lite queue key, compared first on its first element.
+type dstarKey [2]float64
+
+func (k dstarKey) less(other dstarKey) bool {
+	return k[0] < other[0] || k[0] == other[0] && k[1] < other[1]
+}
+
+type dstarEntry struct {
+	p     Point
+	key   dstarKey
+	index int
+}
+
+// dstarQueue is a min-heap of inconsistent cells. It implements
+// heap.Interface.
+type dstarQueue []*dstarEntry
+
+func (q dstarQueue) Len() int {
+	return len(q)
+}
+
+func (q dstarQueue) Less(i, j int) bool {
+	return q[i].key.less(q[j].key)
+}
+
+func (q dstarQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *dstarQueue) Push(x any) {
+	e := x.(*dstarEntry)
+	e.index = len(*q)
+	*q = append(*q, e)
+}
+
+func (q *dstarQueue) Pop() any {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.index = -1
+	*q = old[:n-1]
+	return e
+}
@@ -0,0 +1,162 @@
+package maze
+
+import (
+	"container/heap"
+	"errors"
+)
+
+// Frontier is the set of nodes a search has discovered but not yet expanded.
+// The order in which Remove hands nodes back is what distinguishes one search
+// algorithm from another.
+type Frontier interface {
+	GetFrontier() []*Node
+	Add(i *Node)
+	Remove() (*Node, error)
+	ContainsState(i *Node) bool
+	Empty() bool
+}
+
+// Reprioritizer is implemented by frontiers that support decrease-key, so a
+// cheaper path to a node that is already waiting on the frontier can replace
+// the more expensive one.
+type Reprioritizer interface {
+	Lookup(state Point) *Node
+	Fix(i *Node)
+}
+
+// StackFrontier removes the most recently added node first (LIFO).
+type StackFrontier struct {
+	Frontier []*Node
+	members  frontierMembers
+}
+
+func (sf *StackFrontier) GetFrontier() []*Node {
+	return sf.Frontier
+}
+
+func (sf *StackFrontier) Add(i *Node) {
+	sf.Frontier = append(sf.Frontier, i)
+	sf.members.add(i)
+}
+
+func (sf *StackFrontier) ContainsState(i *Node) bool {
+	return sf.members.has(i.State)
+}
+
+func (sf *StackFrontier) Empty() bool {
+	return len(sf.Frontier) == 0
+}
+
+func (sf *StackFrontier) Remove() (*Node, error) {
+	if len(sf.Frontier) > 0 {
+		node := sf.Frontier[len(sf.Frontier)-1]
+		sf.Frontier = sf.Frontier[:len(sf.Frontier)-1]
+		sf.members.remove(node)
+		return node, nil
+	}
+	return nil, errors.New("frontier is empty")
+}
+
+// QueueFrontier removes the oldest node first (FIFO).
+type QueueFrontier struct {
+	Frontier []*Node
+	members  frontierMembers
+}
+
+func (qf *QueueFrontier) GetFrontier() []*Node {
+	return qf.Frontier
+}
+
+func (qf *QueueFrontier) Add(i *Node) {
+	qf.Frontier = append(qf.Frontier, i)
+	qf.members.add(i)
+}
+
+func (qf *QueueFrontier) ContainsState(i *Node) bool {
+	return qf.members.has(i.State)
+}
+
+func (qf *QueueFrontier) Empty() bool {
+	return len(qf.Frontier) == 0
+}
+
+func (qf *QueueFrontier) Remove() (*Node, error) {
+	if len(qf.Frontier) > 0 {
+		node := qf.Frontier[0]
+		qf.Frontier[0] = nil // avoid memory leak
+		qf.Frontier = qf.Frontier[1:]
+		qf.members.remove(node)
+		return node, nil
+	}
+	return nil, errors.New("frontier is empty")
+}
+
+// PriorityFrontier removes the node with the lowest cost first. Cost is
+// called once when a node is added, and may also fill in the node's cost
+// fields so they can be displayed later.
+type PriorityFrontier struct {
+	Frontier PriorityQueue
+	Cost     func(n *Node) float64
+	members  frontierMembers
+}
+
+func (pf *PriorityFrontier) GetFrontier() []*Node {
+	return pf.Frontier
+}
+
+func (pf *PriorityFrontier) Add(i *Node) {
+	i.priority = pf.Cost(i)
+	heap.Push(&pf.Frontier, i)
+	pf.members.add(i)
+}
+
+// Lookup returns the node on the frontier with the given state, if any.
+func (pf *PriorityFrontier) Lookup(state Point) *Node {
+	return pf.members[state]
+}
+
+// Fix recomputes the cost of a node already on the frontier and restores the
+// heap ordering after its path cost has changed.
+func (pf *PriorityFrontier) Fix(i *Node) {
+	i.priority = pf.Cost(i)
+	heap.Fix(&pf.Frontier, i.index)
+}
+
+func (pf *PriorityFrontier) ContainsState(i *Node) bool {
+	return pf.members.has(i.State)
+}
+
+func (pf *PriorityFrontier) Empty() bool {
+	return len(pf.Frontier) == 0
+}
+
+func (pf *PriorityFrontier) Remove() (*Node, error) {
+	if len(pf.Frontier) > 0 {
+		node := heap.Pop(&pf.Frontier).(*Node)
+		pf.members.remove(node)
+		return node, nil
+	}
+	return nil, errors.New("frontier is empty")
+}
+
+// frontierMembers indexes the nodes on a frontier by state, so membership
+// tests don't have to scan the frontier.
+type frontierMembers map[Point]*Node
+
+func (fm *frontierMembers) add(i *Node) {
+	if *fm == nil {
+		*fm = make(frontierMembers)
+	}
+	(*fm)[i.State] = i
+}
+
+func (fm frontierMembers) remove(i *Node) {
+	if fm[i.State] == i {
+		delete(fm, i.State)
+	}
+}
+
+func (fm frontierMembers) has(state Point) bool {
+	_, ok := fm[state]
+	return ok
+}
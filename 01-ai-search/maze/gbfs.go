@@ -0,0 +1,12 @@
+package maze
+
+// newGreedyBestFirstFrontier always expands the node that looks closest to
+// the goal, ignoring how far it is from the start.
+func newGreedyBestFirstFrontier(m *Maze) Frontier {
+	return &PriorityFrontier{
+		Cost: func(n *Node) float64 {
+			n.CostToGoal = m.Heuristic(n.State)
+			return n.CostToGoal
+		},
+	}
+}
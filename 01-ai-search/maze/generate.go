@@ -0,0 +1,356 @@
+package maze
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// Generator produces random mazes in the same text format that Maze.Load
+// reads. Width and Height are measured in characters and are rounded up to
+// odd numbers so the maze has a wall on every side.
+type Generator struct {
+	Width     int
+	Height    int
+	Algorithm string
+	Braid     float64 // probability of knocking a loop into each dead end
+	Water     float64 // fraction of open cells to flood
+	Seed      int64
+}
+
+// carvers maps algorithm names to functions that carve a perfect maze (one
+// with exactly one path between any two cells) into a grid.
+var carvers = map[string]func(g *mazeGrid, r *rand.Rand){
+	"backtracker": carveBacktracker,
+	"prim":        carvePrim,
+	"kruskal":     carveKruskal,
+	"wilson":      carveWilson,
+}
+
+// GeneratorNames returns the names of all maze generation algorithms, sorted.
+func GeneratorNames() []string {
+	var names []string
+	for name := range carvers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Generate builds a new maze and returns it as rows of characters.
+func (gen Generator) Generate() ([]string, error) {
+	carve, ok := carvers[gen.Algorithm]
+	if !ok {
+		return nil, fmt.Errorf("invalid maze algorithm %q, must be one of: %s", gen.Algorithm, strings.Join(GeneratorNames(), ", "))
+	}
+
+	if gen.Width < 5 || gen.Height < 5 {
+		return nil, errors.New("maze must be at least 5x5")
+	}
+
+	if gen.Braid < 0 || gen.Braid > 1 || gen.Water < 0 || gen.Water > 1 {
+		return nil, errors.New("braid and water must be between 0 and 1")
+	}
+
+	r := rand.New(rand.NewSource(gen.Seed))
+	g := newMazeGrid(gen.Height|1, gen.Width|1)
+
+	carve(g, r)
+	g.braid(r, gen.Braid)
+	g.flood(r, gen.Water)
+
+	g.cells[1][1] = 'A'
+	g.cells[g.height-2][g.width-2] = 'B'
+
+	var rows []string
+	for _, row := range g.cells {
+		rows = append(rows, string(row))
+	}
+	return rows, nil
+}
+
+// Write generates a maze and writes it to w.
+func (gen Generator) Write(w io.Writer) error {
+	rows, err := gen.Generate()
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, row := range rows {
+		_, _ = bw.WriteString(row)
+		_ = bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// mazeGrid is a grid of maze characters where rooms sit on odd rows and
+// columns and the even rows and columns hold the walls between them.
+type mazeGrid struct {
+	height int
+	width  int
+	cells  [][]byte
+}
+
+func newMazeGrid(height, width int) *mazeGrid {
+	g := &mazeGrid{height: height, width: width}
+	for range height {
+		g.cells = append(g.cells, []byte(strings.Repeat("#", width)))
+	}
+	return g
+}
+
+// rooms returns every room position in the grid.
+func (g *mazeGrid) rooms() []Point {
+	var rooms []Point
+	for row := 1; row < g.height-1; row += 2 {
+		for col := 1; col < g.width-1; col += 2 {
+			rooms = append(rooms, Point{Row: row, Col: col})
+		}
+	}
+	return rooms
+}
+
+// adjacentRooms returns the rooms two steps away from p in each direction.
+func (g *mazeGrid) adjacentRooms(p Point) []Point {
+	var rooms []Point
+	for _, d := range []Point{{Row: -2}, {Row: 2}, {Col: -2}, {Col: 2}} {
+		n := Point{Row: p.Row + d.Row, Col: p.Col + d.Col}
+		if n.Row > 0 && n.Row < g.height-1 && n.Col > 0 && n.Col < g.width-1 {
+			rooms = append(rooms, n)
+		}
+	}
+	return rooms
+}
+
+func (g *mazeGrid) open(p Point) {
+	g.cells[p.Row][p.Col] = ' '
+}
+
+func (g *mazeGrid) isOpen(p Point) bool {
+	return g.cells[p.Row][p.Col] != '#'
+}
+
+// connect opens two adjacent rooms and the wall between them.
+func (g *mazeGrid) connect(a, b Point) {
+	g.open(a)
+	g.open(b)
+	g.open(Point{Row: (a.Row + b.Row) / 2, Col: (a.Col + b.Col) / 2})
+}
+
+// carveBacktracker is a randomised depth first search: it walks to a random
+// unvisited neighbour until it gets stuck, then backtracks. It produces long
+// winding corridors with few branches.
+func carveBacktracker(g *mazeGrid, r *rand.Rand) {
+	start := Point{Row: 1, Col: 1}
+	g.open(start)
+	stack := []Point{start}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+
+		var unvisited []Point
+		for _, n := range g.adjacentRooms(current) {
+			if !g.isOpen(n) {
+				unvisited = append(unvisited, n)
+			}
+		}
+
+		if len(unvisited) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := unvisited[r.Intn(len(unvisited))]
+		g.connect(current, next)
+		stack = append(stack, next)
+	}
+}
+
+// carvePrim is randomised Prim's algorithm: the maze grows outwards from a
+// single room by repeatedly connecting a random room on its border. It
+// produces many short dead ends.
+func carvePrim(g *mazeGrid, r *rand.Rand) {
+	type edge struct{ from, to Point }
+
+	start := Point{Row: 1, Col: 1}
+	g.open(start)
+
+	var edges []edge
+	for _, n := range g.adjacentRooms(start) {
+		edges = append(edges, edge{start, n})
+	}
+
+	for len(edges) > 0 {
+		i := r.Intn(len(edges))
+		e := edges[i]
+		edges[i] = edges[len(edges)-1]
+		edges = edges[:len(edges)-1]
+
+		if g.isOpen(e.to) {
+			continue
+		}
+
+		g.connect(e.from, e.to)
+		for _, n := range g.adjacentRooms(e.to) {
+			if !g.isOpen(n) {
+				edges = append(edges, edge{e.to, n})
+			}
+		}
+	}
+}
+
+// carveKruskal is randomised Kruskal's algorithm: every wall between two
+// rooms is considered in random order and removed if the rooms are not yet
+// connected, tracked with a union-find.
+func carveKruskal(g *mazeGrid, r *rand.Rand) {
+	type edge struct{ from, to Point }
+
+	rooms := g.rooms()
+	parent := make(map[Point]Point, len(rooms))
+	for _, p := range rooms {
+		parent[p] = p
+	}
+
+	var find func(p Point) Point
+	find = func(p Point) Point {
+		if parent[p] != p {
+			parent[p] = find(parent[p])
+		}
+		return parent[p]
+	}
+
+	var edges []edge
+	for _, p := range rooms {
+		for _, n := range g.adjacentRooms(p) {
+			// only look right and down so every wall is listed once
+			if n.Row > p.Row || n.Col > p.Col {
+				edges = append(edges, edge{p, n})
+			}
+		}
+	}
+	r.Shuffle(len(edges), func(i, j int) { edges[i], edges[j] = edges[j], edges[i] })
+
+	for _, e := range edges {
+		a, b := find(e.from), find(e.to)
+		if a != b {
+			parent[a] = b
+			g.connect(e.from, e.to)
+		}
+	}
+}
+
+// carveWilson is Wilson's algorithm: loop-erased random walks from unvisited
+// rooms until they hit the maze. It picks uniformly among all possible
+// mazes, so it has no directional bias.
+func carveWilson(g *mazeGrid, r *rand.Rand) {
+	rooms := g.rooms()
+	g.open(rooms[r.Intn(len(rooms))])
+
+	for _, start := range rooms {
+		if g.isOpen(start) {
+			continue
+		}
+
+		// walk randomly until we reach the maze, remembering only the last
+		// direction taken from each room, which erases any loops
+		next := make(map[Point]Point)
+		current := start
+		for !g.isOpen(current) {
+			neighbours := g.adjacentRooms(current)
+			next[current] = neighbours[r.Intn(len(neighbours))]
+			current = next[current]
+		}
+
+		var path []Point
+		for current = start; !g.isOpen(current); current = next[current] {
+			path = append(path, current)
+		}
+		for _, p := range path {
+			g.connect(p, next[p])
+		}
+	}
+}
+
+// braid removes dead ends, each with the given probability, by knocking
+// through one of their walls into a neighbouring room. This adds loops, so
+// there is more than one route between rooms.
+func (g *mazeGrid) braid(r *rand.Rand, probability float64) {
+	if probability == 0 {
+		return
+	}
+
+	for _, p := range g.rooms() {
+		var walls []Point
+		for _, n := range g.adjacentRooms(p) {
+			wall := Point{Row: (p.Row + n.Row) / 2, Col: (p.Col + n.Col) / 2}
+			if !g.isOpen(wall) {
+				walls = append(walls, wall)
+			}
+		}
+
+		// a dead end is a room with only one way out
+		if len(g.adjacentRooms(p))-len(walls) != 1 || r.Float64() >= probability {
+			continue
+		}
+
+		g.open(walls[r.Intn(len(walls))])
+	}
+}
+
+// flood turns roughly the given fraction of open cells into water, grown as
+// a few blobs from random starting cells so they form lakes and rivers
+// rather than scattered puddles.
+func (g *mazeGrid) flood(r *rand.Rand, fraction float64) {
+	if fraction == 0 {
+		return
+	}
+
+	var open []Point
+	for row := range g.cells {
+		for col := range g.cells[row] {
+			if g.cells[row][col] == ' ' {
+				open = append(open, Point{Row: row, Col: col})
+			}
+		}
+	}
+
+	target := int(fraction * float64(len(open)))
+	flooded := 0
+
+	for flooded < target {
+		// each region is grown from a random open cell
+		seed := open[r.Intn(len(open))]
+		if g.cells[seed.Row][seed.Col] != ' ' {
+			continue
+		}
+
+		size := 1 + r.Intn(max(1, target/4))
+		region := []Point{seed}
+		for len(region) > 0 && size > 0 && flooded < target {
+			i := r.Intn(len(region))
+			p := region[i]
+			region[i] = region[len(region)-1]
+			region = region[:len(region)-1]
+
+			if g.cells[p.Row][p.Col] != ' ' {
+				continue
+			}
+
+			g.cells[p.Row][p.Col] = 'w'
+			flooded++
+			size--
+
+			for _, d := range []Point{{Row: -1}, {Row: 1}, {Col: -1}, {Col: 1}} {
+				n := Point{Row: p.Row + d.Row, Col: p.Col + d.Col}
+				if g.cells[n.Row][n.Col] == ' ' {
+					region = append(region, n)
+				}
+			}
+		}
+	}
+}
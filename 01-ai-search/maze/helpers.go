@@ -0,0 +1,40 @@
+package maze
+
+import (
+	"fmt"
+	"math"
+)
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// opposites maps each action to the one that undoes it.
+var opposites = map[string]string{
+	"up":         "down",
+	"down":       "up",
+	"left":       "right",
+	"right":      "left",
+	"up-left":    "down-right",
+	"down-right": "up-left",
+	"up-right":   "down-left",
+	"down-left":  "up-right",
+	"east":       "west",
+	"west":       "east",
+	"northeast":  "southwest",
+	"southwest":  "northeast",
+	"northwest":  "southeast",
+	"southeast":  "northwest",
+}
+
+// FormatCost formats a path cost for display, only showing decimals when
+// diagonal moves have made it fractional.
+func FormatCost(cost float64) string {
+	if cost == math.Trunc(cost) {
+		return fmt.Sprintf("%.0f", cost)
+	}
+	return fmt.Sprintf("%.2f", cost)
+}
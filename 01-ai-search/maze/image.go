@@ -0,0 +1,123 @@
+package maze
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+
+	"github.com/StephaneBunel/bresenham"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// variables for colour
+var (
+	green     = color.RGBA{G: 255, A: 255}
+	darkGreen = color.RGBA{R: 1, G: 100, B: 32, A: 255}
+	red       = color.RGBA{R: 255, A: 255}
+	yellow    = color.RGBA{R: 255, G: 255, B: 101, A: 255}
+	gray      = color.RGBA{R: 125, G: 125, B: 125, A: 255}
+	orange    = color.RGBA{R: 255, G: 140, B: 25, A: 255}
+	blue      = color.RGBA{R: 14, G: 180, B: 173, A: 255}
+	lavender  = color.RGBA{R: 200, G: 180, B: 255, A: 255}
+	black     = color.RGBA{A: 255}
+	white     = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+)
+
+// legColours tell the legs of a route through several goals apart.
+var legColours = []color.RGBA{
+	darkGreen,
+	{R: 40, G: 70, B: 200, A: 255},
+	{R: 160, G: 30, B: 160, A: 255},
+	{R: 200, G: 90, B: 0, A: 255},
+}
+
+// pngRenderer draws the maze as a png image.
+type pngRenderer struct{}
+
+func (pngRenderer) Render(w io.Writer, g *Maze) error {
+	return png.Encode(w, g.render())
+}
+
+// render draws the whole maze in its current state.
+func (g *Maze) render() *image.RGBA {
+	img := image.NewRGBA(image.Rectangle{Max: g.imageSize()})
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: color.Black}, image.Point{}, draw.Src)
+
+	for i, row := range g.Walls {
+		for j := range row {
+			g.drawCell(img, Point{Row: i, Col: j})
+		}
+	}
+
+	if g.showPath() {
+		g.drawPath(img)
+	}
+
+	return img
+}
+
+// drawPath draws a line through the centre of every cell in the solution,
+// in a different colour for each leg of a route.
+func (g *Maze) drawPath(img *image.RGBA) {
+	centre := func(p Point) image.Point {
+		b := g.cellBounds(p)
+		return image.Point{X: (b.Min.X + b.Max.X) / 2, Y: (b.Min.Y + b.Max.Y) / 2}
+	}
+
+	for i, leg := range g.pathLegs() {
+		c := legColours[i%len(legColours)]
+		for j := 1; j < len(leg); j++ {
+			from, to := centre(leg[j-1]), centre(leg[j])
+			// a few parallel lines, so the path is easy to see
+			for d := -1; d <= 1; d++ {
+				bresenham.DrawLine(img, from.X+d, from.Y, to.X+d, to.Y, c)
+				bresenham.DrawLine(img, from.X, from.Y+d, to.X, to.Y+d, c)
+			}
+		}
+	}
+}
+
+// drawCell draws a single cell, along with the grid lines on its top and
+// left edges, so any cell can be redrawn on its own when it changes.
+func (g *Maze) drawCell(img *image.RGBA, p Point) {
+	bounds := g.cellBounds(p)
+	size := bounds.Dx()
+
+	patch := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(patch, patch.Bounds(), &image.Uniform{C: g.cellColour(p)}, image.Point{}, draw.Src)
+
+	// the font is too big for the text to fit in small cells
+	if size >= 40 {
+		for _, label := range g.cellLabels(p) {
+			drawLabel(patch, label)
+		}
+	}
+
+	draw.Draw(img, bounds, patch, image.Point{}, draw.Src)
+
+	// draw the grid
+	x, y := bounds.Min.X, bounds.Min.Y
+	bresenham.DrawLine(img, x, y, x+size, y, gray)
+	bresenham.DrawLine(img, x, y, x, y+size, gray)
+}
+
+// drawLabel writes a label into a cell's patch.
+func drawLabel(patch *image.RGBA, label cellLabel) {
+	size := float64(patch.Bounds().Dx())
+	d := &font.Drawer{
+		Dst:  patch,
+		Src:  image.NewUniform(label.Colour),
+		Face: basicfont.Face7x13,
+	}
+
+	x := fixed.I(int(label.X * size))
+	if label.Right {
+		x -= d.MeasureString(label.Text)
+	}
+	d.Dot = fixed.Point26_6{X: x, Y: fixed.I(int(label.Y * size))}
+	d.DrawString(label.Text)
+}
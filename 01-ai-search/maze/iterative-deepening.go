@@ -0,0 +1,140 @@
+package maze
+
+import (
+	"context"
+	"math"
+)
+
+// IterativeDeepeningSearch repeats a depth first search with an increasing
+// bound on f(n), only keeping the current path in memory instead of a
+// frontier. Bounding on depth gives iterative deepening DFS; bounding on
+// g(n) + h(n) gives IDA*. Paths that loop back onto themselves are pruned by
+// checking the cells on the current path. With the maze's Transpositions set,
+// each iteration also remembers the lowest f at which it reached every cell,
+// so paths that rejoin a cell more expensively are pruned too; that costs
+// memory for the whole grid but stops the search blowing up exponentially on
+// mazes with many loops.
+type IterativeDeepeningSearch struct {
+	Name string
+	Game *Maze
+	F    func(n *Node, depth int) float64
+
+	onPath map[Point]bool
+	best   []float64
+}
+
+// newIterativeDeepeningDFS bounds each iteration by the number of moves.
+func newIterativeDeepeningDFS(m *Maze) Solver {
+	return &IterativeDeepeningSearch{
+		Name: "iterative deepening depth first search",
+		Game: m,
+		F: func(_ *Node, depth int) float64 {
+			return float64(depth)
+		},
+	}
+}
+
+// newIDAStar bounds each iteration by the same estimate A* uses, the path
+// cost so far plus the movement model's heuristic.
+func newIDAStar(m *Maze) Solver {
+	return &IterativeDeepeningSearch{
+		Name: "IDA* search",
+		Game: m,
+		F: func(n *Node, _ int) float64 {
+			n.CostToGoal = m.Heuristic(n.State)
+			n.EstimatedCostToGoal = n.PathCost + n.CostToGoal
+			return n.EstimatedCostToGoal
+		},
+	}
+}
+
+func (id *IterativeDeepeningSearch) Solve(ctx context.Context) error {
+	id.Game.reportf(Started, "%s", id.Name)
+
+	id.Game.resetSearch()
+
+	start := &Node{State: id.Game.Start}
+	id.Game.CurrentNode = start
+	threshold := id.F(start, 0)
+
+	for {
+		id.Game.reportf(Detail, "searching with threshold %v", threshold)
+
+		id.onPath = make(map[Point]bool)
+		if id.Game.Transpositions {
+			id.best = make([]float64, id.Game.Height*id.Game.Width)
+			for i := range id.best {
+				id.best[i] = math.Inf(1)
+			}
+		}
+
+		goal, next, err := id.search(ctx, start, 0, threshold)
+		if err != nil {
+			return id.Game.giveUp(err)
+		}
+		if goal != nil {
+			id.Game.setSolution(solutionFrom(goal))
+			return nil
+		}
+
+		// nothing was cut off by the threshold, so there is no solution
+		if math.IsInf(next, 1) {
+			return nil
+		}
+		threshold = next
+	}
+}
+
+// search explores depth first from n without exceeding threshold. It returns
+// the goal node if found, otherwise the smallest f that exceeded the
+// threshold, which becomes the threshold for the next iteration.
+func (id *IterativeDeepeningSearch) search(ctx context.Context, n *Node, depth int, threshold float64) (*Node, float64, error) {
+	if id.onPath[n.State] {
+		return nil, math.Inf(1), nil
+	}
+
+	f := id.F(n, depth)
+	if f > threshold {
+		return nil, f, nil
+	}
+
+	if id.best != nil {
+		i := n.State.Row*id.Game.Width + n.State.Col
+		if f >= id.best[i] {
+			return nil, math.Inf(1), nil
+		}
+		id.best[i] = f
+	}
+
+	// the only nodes held in memory are those on the current path
+	id.Game.trackFrontier(depth + 1)
+
+	if err := id.Game.visit(ctx, n); err != nil {
+		return nil, 0, err
+	}
+	if id.Game.isGoal(n.State) {
+		return n, f, nil
+	}
+	id.Game.frame()
+
+	id.onPath[n.State] = true
+	defer delete(id.onPath, n.State)
+
+	next := math.Inf(1)
+	for _, x := range id.Game.Neighbors(n) {
+		child := &Node{
+			State:    x.State,
+			Parent:   n,
+			Action:   x.Action,
+			PathCost: x.PathCost,
+		}
+
+		goal, t, err := id.search(ctx, child, depth+1, threshold)
+		if goal != nil || err != nil {
+			return goal, t, err
+		}
+		next = min(next, t)
+	}
+
+	return nil, next, nil
+}
@@ -0,0 +1,302 @@
+package maze
+
+import (
+	"context"
+)
+
+// JumpPointSearch is A* for grids where every open cell costs the same. It
+// skips over runs of cells that any optimal path would pass straight
+// through, only adding "jump points" (cells where the path might have to
+// turn) to the frontier, so it expands far fewer nodes than A* on open
+// mazes. Diagonal moves follow the same no corner cutting rule as Neighbors.
+//
+// It only works when all moves of the same kind cost the same, so on mazes
+// with weighted terrain, or on hex grids, it falls back to plain A*.
+type JumpPointSearch struct {
+	Game     *Maze
+	Frontier *PriorityFrontier
+}
+
+func newJumpPointSearch(m *Maze) Solver {
+	return &JumpPointSearch{Game: m}
+}
+
+func (js *JumpPointSearch) Solve(ctx context.Context) error {
+	if !js.Game.uniformCost() || js.Game.movement().Hex {
+		js.Game.reportf(Notice, "maze has weighted terrain or hex movement, falling back to A* search")
+		return strategies["astar"].NewSearch(js.Game).Solve(ctx)
+	}
+
+	js.Game.reportf(Started, "jump point search")
+
+	js.Game.resetSearch()
+	js.Frontier = newAStarFrontier(js.Game).(*PriorityFrontier)
+	defer func() {
+		js.Game.recordFrontier(js.Frontier.GetFrontier())
+	}()
+
+	start := &Node{State: js.Game.Start}
+	js.Frontier.Add(start)
+	js.Game.CurrentNode = start
+	js.Game.trackFrontier(1)
+
+	for !js.Frontier.Empty() {
+		currentNode, err := js.Frontier.Remove()
+		if err != nil {
+			return err
+		}
+
+		if err := js.Game.visit(ctx, currentNode); err != nil {
+			// jump points are joined by straight runs of cells, which the
+			// partial path needs filling in like a full one
+			if js.Game.closest != nil {
+				js.Game.closest = js.expandPath(js.Game.closest)
+			}
+			return js.Game.giveUp(err)
+		}
+
+		if js.Game.isGoal(currentNode.State) {
+			js.Game.setSolution(solutionFrom(js.expandPath(currentNode)))
+			return nil
+		}
+
+		js.Game.frame()
+
+		for _, dir := range js.directions(currentNode) {
+			next := Point{Row: currentNode.State.Row + dir.Row, Col: currentNode.State.Col + dir.Col}
+			jumpPoint, ok := js.jump(next, dir.Row, dir.Col)
+			if !ok || js.Game.inExplored(jumpPoint) {
+				continue
+			}
+
+			cost := currentNode.PathCost + js.distance(currentNode.State, jumpPoint)
+			if existing := js.Frontier.Lookup(jumpPoint); existing != nil {
+				if cost < existing.PathCost {
+					existing.Parent = currentNode
+					existing.PathCost = cost
+					js.Frontier.Fix(existing)
+				}
+				continue
+			}
+
+			js.Frontier.Add(&Node{
+				State:    jumpPoint,
+				Parent:   currentNode,
+				PathCost: cost,
+			})
+		}
+
+		js.Game.trackFrontier(len(js.Frontier.GetFrontier()))
+	}
+	return nil
+}
+
+// directions returns the directions worth searching from a node. From the
+// start that is every legal move; elsewhere, moves that an optimal path
+// could not take, given the direction it arrived from, are pruned.
+func (js *JumpPointSearch) directions(n *Node) []Point {
+	p := n.State
+	open := func(dr, dc int) bool {
+		return js.Game.open(Point{Row: p.Row + dr, Col: p.Col + dc})
+	}
+
+	var dirs []Point
+	add := func(dr, dc int) {
+		dirs = append(dirs, Point{Row: dr, Col: dc})
+	}
+
+	if n.Parent == nil {
+		for _, move := range js.Game.movement().Moves(p) {
+			if js.Game.allowed(p, move) {
+				add(move.Row, move.Col)
+			}
+		}
+		return dirs
+	}
+
+	dr, dc := sign(p.Row-n.Parent.State.Row), sign(p.Col-n.Parent.State.Col)
+	diagonal := js.Game.movement().Diagonal
+
+	switch {
+	case dr != 0 && dc != 0:
+		if open(dr, 0) {
+			add(dr, 0)
+		}
+		if open(0, dc) {
+			add(0, dc)
+		}
+		if open(dr, 0) && open(0, dc) && open(dr, dc) {
+			add(dr, dc)
+		}
+	case !diagonal:
+		// on a four connected grid, turning is always possible
+		if dc != 0 {
+			add(0, dc)
+			add(-1, 0)
+			add(1, 0)
+		} else {
+			add(dr, 0)
+			add(0, -1)
+			add(0, 1)
+		}
+	case dc != 0:
+		ahead, up, down := open(0, dc), open(-1, 0), open(1, 0)
+		if ahead {
+			add(0, dc)
+			if up && open(-1, dc) {
+				add(-1, dc)
+			}
+			if down && open(1, dc) {
+				add(1, dc)
+			}
+		}
+		if up {
+			add(-1, 0)
+		}
+		if down {
+			add(1, 0)
+		}
+	default:
+		ahead, left, right := open(dr, 0), open(0, -1), open(0, 1)
+		if ahead {
+			add(dr, 0)
+			if left && open(dr, -1) {
+				add(dr, -1)
+			}
+			if right && open(dr, 1) {
+				add(dr, 1)
+			}
+		}
+		if left {
+			add(0, -1)
+		}
+		if right {
+			add(0, 1)
+		}
+	}
+
+	// pruning can suggest blocked cells on a four connected grid
+	var legal []Point
+	for _, d := range dirs {
+		if open(d.Row, d.Col) {
+			legal = append(legal, d)
+		}
+	}
+	return legal
+}
+
+// jump travels from p in direction (dr, dc) until it finds the goal, a cell
+// with a forced neighbour (somewhere an optimal path might turn), or a wall.
+func (js *JumpPointSearch) jump(p Point, dr, dc int) (Point, bool) {
+	open := func(row, col int) bool {
+		return js.Game.open(Point{Row: row, Col: col})
+	}
+	diagonal := js.Game.movement().Diagonal
+
+	for {
+		if !js.Game.open(p) {
+			return Point{}, false
+		}
+
+		if js.Game.isGoal(p) {
+			return p, true
+		}
+
+		r, c := p.Row, p.Col
+		switch {
+		case dr != 0 && dc != 0:
+			// a diagonal is a jump point if either straight line from it
+			// leads to one
+			if _, ok := js.jump(Point{Row: r, Col: c + dc}, 0, dc); ok {
+				return p, true
+			}
+			if _, ok := js.jump(Point{Row: r + dr, Col: c}, dr, 0); ok {
+				return p, true
+			}
+			if !open(r+dr, c) || !open(r, c+dc) {
+				return Point{}, false
+			}
+		case dc != 0:
+			if (open(r-1, c) && !open(r-1, c-dc)) || (open(r+1, c) && !open(r+1, c-dc)) {
+				return p, true
+			}
+		default:
+			if (open(r, c-1) && !open(r-dr, c-1)) || (open(r, c+1) && !open(r-dr, c+1)) {
+				return p, true
+			}
+
+			// without diagonal moves, a vertical run must stop wherever a
+			// horizontal one could find a jump point
+			if !diagonal {
+				if _, ok := js.jump(Point{Row: r, Col: c + 1}, 0, 1); ok {
+					return p, true
+				}
+				if _, ok := js.jump(Point{Row: r, Col: c - 1}, 0, -1); ok {
+					return p, true
+				}
+			}
+		}
+
+		p = Point{Row: r + dr, Col: c + dc}
+	}
+}
+
+// distance returns the cost of the straight or diagonal run between two
+// jump points.
+func (js *JumpPointSearch) distance(from, to Point) float64 {
+	return js.Game.movement().Heuristic(from, to)
+}
+
+// expandPath turns the chain of jump points ending at goal into a chain of
+// nodes for every cell along the way, so the solution has one action per
+// move like every other solver.
+func (js *JumpPointSearch) expandPath(goal *Node) *Node {
+	var jumpPoints []*Node
+	for n := goal; n != nil; n = n.Parent {
+		jumpPoints = append([]*Node{n}, jumpPoints...)
+	}
+
+	actions := make(map[Point]string)
+	for _, move := range js.Game.movement().Moves(goal.State) {
+		actions[Point{Row: move.Row, Col: move.Col}] = move.Action
+	}
+
+	current := &Node{State: jumpPoints[0].State}
+	for _, jp := range jumpPoints[1:] {
+		dr, dc := sign(jp.State.Row-current.State.Row), sign(jp.State.Col-current.State.Col)
+		for current.State != jp.State {
+			next := Point{Row: current.State.Row + dr, Col: current.State.Col + dc}
+			current = &Node{
+				State:    next,
+				Parent:   current,
+				Action:   actions[Point{Row: dr, Col: dc}],
+				PathCost: current.PathCost + js.Game.moveCost(current.State, next),
+			}
+		}
+	}
+
+	return current
+}
+
+// uniformCost reports whether every open cell in the maze costs the same to
+// enter.
+func (g *Maze) uniformCost() bool {
+	for _, row := range g.Walls {
+		for _, cell := range row {
+			if !cell.wall && cell.Terrain.Cost != terrains[' '].Cost {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func sign(x int) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+	return 0
+}
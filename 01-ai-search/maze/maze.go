@@ -0,0 +1,220 @@
+// Package maze reads mazes and solves them with a choice of search
+// algorithms, from depth first search to A*, jump point search and D* Lite.
+//
+// A maze is loaded with Load or Read, and solved with Solve:
+//
+//	var m maze.Maze
+//	if err := m.Load("maze.txt"); err != nil {
+//		return err
+//	}
+//	strategy, err := maze.LookupStrategy("astar")
+//	if err != nil {
+//		return err
+//	}
+//	result, err := maze.Solve(ctx, &m, strategy)
+//
+// Nothing in the package prints. Solvers report what they are doing through
+// the maze's Progress callback, and the maze can be drawn afterwards with
+// any of the Renderers.
+package maze
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+)
+
+const (
+	DFS = iota
+	BFS
+	GBFS
+	ASTAR
+	DIJKSTRA
+	BIBFS
+	IDDFS
+	IDASTAR
+	JPS
+	DSTAR
+)
+
+type Point struct {
+	Row int
+	Col int
+}
+
+type Wall struct {
+	State   Point
+	Terrain Terrain
+	wall    bool
+}
+
+type Node struct {
+	index               int
+	State               Point
+	Parent              *Node
+	Action              string
+	PathCost            float64
+	CostToGoal          float64
+	EstimatedCostToGoal float64
+	priority            float64
+}
+
+type Solution struct {
+	Actions   []string
+	Cells     []Point
+	Cost      float64
+	Legs      []Leg
+	Route     string  // how the goals were visited, see Maze.Route; empty if not known
+	Waypoints []Point // the goals a waypoints route visits, in order
+}
+
+// Maze is a maze and the options for searching it. The cells are only read
+// by searches; everything a search finds out goes in the embedded State.
+type Maze struct {
+	Name           string
+	Height         int
+	Width          int
+	Start          Point
+	Goal           Point
+	Goals          []Point
+	GoalLabels     map[Point]string
+	targets        []Point
+	Walls          [][]Wall
+	View           View
+	Limits         Limits
+	MaxCells       int // largest grid Read and ReadJSON will build, if not 0
+	Steps          int
+	Debug          bool
+	KeepTree       bool // keep every node expanded, for SearchTree
+	Transpositions bool // let IDDFS and IDA* prune by the cheapest f seen at each cell, using memory for the whole grid
+	Progress       func(Progress)
+	SearchType     int
+	Animation      *Animator
+	Movement       Movement
+	Rand           *rand.Rand
+	seed           int64
+
+	State
+}
+
+// State is what a search leaves behind: the cells it expanded, the path it
+// found and how much work it took. Each search starts with a fresh State.
+type State struct {
+	CurrentNode *Node
+	Solution    Solution
+	Explored    []Point
+	PathCosts   map[Point]float64
+	NumExplored int
+	MaxFrontier int
+
+	explored      *PointSet
+	solution      *PointSet
+	frontier      *PointSet
+	closest       *Node
+	closestH      float64
+	exploredIndex map[Point]int
+	tree          []expansion
+}
+
+// Load reads a maze file, in JSON if its name ends in .json and in the text
+// format otherwise.
+func (g *Maze) Load(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %s", filename, err)
+	}
+	defer f.Close()
+
+	read := g.Read
+	if strings.HasSuffix(filename, ".json") {
+		read = g.ReadJSON
+	}
+
+	if err := read(f); err != nil {
+		return fmt.Errorf("cannot read file %s: %s", filename, err)
+	}
+	return nil
+}
+
+// SetSeed gives the maze its own random source, so the order in which
+// solvers explore neighbours is reproducible.
+func (g *Maze) SetSeed(seed int64) {
+	g.seed = seed
+	g.Rand = rand.New(rand.NewSource(seed))
+}
+
+// Seed returns the seed last given to SetSeed.
+func (g *Maze) Seed() int64 {
+	return g.seed
+}
+
+// Fork returns a copy of the maze for another search to run on. The copy
+// shares the maze's cells but has its own State, random source and no
+// animation, so searches on different forks can run at the same time. The
+// Progress callback is shared, and must be safe to call from several
+// goroutines if they do.
+func (g *Maze) Fork() *Maze {
+	f := *g
+	f.State = State{}
+	f.Animation = nil
+	if g.Rand != nil {
+		f.SetSeed(g.seed)
+	}
+	return &f
+}
+
+// resetSearch clears the results of any previous search. The current node
+// is kept, so an animation knows to redraw it.
+func (g *Maze) resetSearch() {
+	g.State = State{
+		CurrentNode: g.CurrentNode,
+		PathCosts:   make(map[Point]float64),
+		explored:    NewPointSet(g.Height, g.Width),
+	}
+}
+
+// markExplored records p as expanded, keeping the order in which cells were
+// explored for animation alongside a set for fast lookups.
+func (g *Maze) markExplored(p Point) {
+	if g.explored.Has(p) {
+		return
+	}
+	g.Explored = append(g.Explored, p)
+	g.explored.Add(p)
+}
+
+func (g *Maze) inExplored(p Point) bool {
+	return g.explored.Has(p)
+}
+
+func (g *Maze) setSolution(solution Solution) {
+	g.Solution = solution
+	g.solution = NewPointSet(g.Height, g.Width)
+	for _, step := range solution.Cells {
+		g.solution.Add(step)
+	}
+}
+
+func (g *Maze) inSolution(x Point) bool {
+	return g.solution.Has(x)
+}
+
+// goals returns the cells a search is trying to reach: normally just the
+// goal, but any of several when routing to the nearest of them.
+func (g *Maze) goals() []Point {
+	if len(g.targets) > 0 {
+		return g.targets
+	}
+	return []Point{g.Goal}
+}
+
+// isGoal reports whether reaching p ends the current search.
+func (g *Maze) isGoal(p Point) bool {
+	for _, goal := range g.goals() {
+		if p == goal {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,438 @@
+package maze
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Mazes are stored in one of three forms.
+//
+// Version 1 text files are just the grid: '#' for walls, ' ' for open
+// ground, 'w' for water, 'A' for the start, 'B' for the goal and the digits
+// 1-9 for further goals.
+//
+// Version 2 text files start with a "maze v2" line and a header, then the
+// grid after a line of "---":
+//
+//	maze v2
+//	name: muddy corridor
+//	movement: grid8
+//	terrain: ~ mud 5
+//	goals: 2 1 B
+//	---
+//	#######
+//	#A~1~2#
+//	#~~B###
+//	#######
+//
+// The header may name the maze, pick a movement model, add terrain symbols
+// with their cost, and give the order goals are visited in. Every row of a
+// version 2 grid must be the same width.
+//
+// JSON files hold exactly what a version 2 file does, with the grid as a
+// list of rows.
+const (
+	mazeV2    = "maze v2"
+	headerEnd = "---"
+)
+
+// MazeFile is a maze as it is written down, before it has been checked.
+type MazeFile struct {
+	Version  int           `json:"version"`
+	Name     string        `json:"name,omitempty"`
+	Movement string        `json:"movement,omitempty"`
+	Terrain  []TerrainSpec `json:"terrain,omitempty"`
+	Goals    []string      `json:"goals,omitempty"`
+	Rows     []string      `json:"rows"`
+}
+
+// TerrainSpec is an entry in a maze file's terrain legend.
+type TerrainSpec struct {
+	Symbol string  `json:"symbol"`
+	Name   string  `json:"name"`
+	Cost   float64 `json:"cost"`
+}
+
+// reserved are the symbols with a fixed meaning, which the terrain legend
+// can't redefine.
+const reserved = "# AB123456789"
+
+// terrain checks the spec and returns the terrain it describes.
+func (t TerrainSpec) terrain() (Terrain, error) {
+	symbol := []rune(t.Symbol)
+	switch {
+	case len(symbol) != 1:
+		return Terrain{}, fmt.Errorf("terrain symbol %q must be a single character", t.Symbol)
+	case strings.ContainsRune(reserved, symbol[0]):
+		return Terrain{}, fmt.Errorf("terrain symbol %q is reserved", t.Symbol)
+	case t.Name == "" || strings.ContainsAny(t.Name, " \t"):
+		return Terrain{}, fmt.Errorf("terrain name %q must be a single word", t.Name)
+	case math.IsNaN(t.Cost) || math.IsInf(t.Cost, 0):
+		return Terrain{}, fmt.Errorf("terrain %s costs %v, but costs must be finite numbers", t.Name, t.Cost)
+	case t.Cost < 1:
+		// the heuristics assume open ground is the cheapest terrain
+		return Terrain{}, fmt.Errorf("terrain %s costs %v, but nothing may cost less than open ground (1)", t.Name, t.Cost)
+	}
+	return Terrain{Symbol: symbol[0], Name: t.Name, Cost: t.Cost}, nil
+}
+
+// checkGoals makes sure a goal order only lists each goal label once.
+func checkGoals(labels []string) error {
+	seen := make(map[string]bool)
+	for _, label := range labels {
+		if len(label) != 1 || !strings.Contains("B123456789", label) {
+			return fmt.Errorf("%q is not a goal label (want B or 1-9)", label)
+		}
+		if seen[label] {
+			return fmt.Errorf("goal %s is listed twice", label)
+		}
+		seen[label] = true
+	}
+	return nil
+}
+
+// locator describes where a cell of the grid came from, for error messages.
+// A negative col means the whole row.
+type locator func(row, col int) string
+
+func textLocator(firstLine int) locator {
+	return func(row, col int) string {
+		if col < 0 {
+			return fmt.Sprintf("line %d", firstLine+row)
+		}
+		return fmt.Sprintf("line %d, column %d", firstLine+row, col+1)
+	}
+}
+
+func jsonLocator(row, col int) string {
+	if col < 0 {
+		return fmt.Sprintf("rows[%d]", row)
+	}
+	return fmt.Sprintf("rows[%d], column %d", row, col+1)
+}
+
+// Read parses a maze in either of the text formats.
+func (g *Maze) Read(r io.Reader) error {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSuffix(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != mazeV2 {
+		return g.build(MazeFile{Version: 1, Rows: lines}, textLocator(1))
+	}
+
+	mf := MazeFile{Version: 2}
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == headerEnd {
+			mf.Rows = lines[i+1:]
+			return g.build(mf, textLocator(i+2))
+		}
+		if line == "" {
+			continue
+		}
+
+		if err := mf.parseHeader(line); err != nil {
+			return fmt.Errorf("line %d: %s", i+1, err)
+		}
+	}
+
+	return fmt.Errorf("line %d: header has no end (want a line of %q before the grid)", len(lines), headerEnd)
+}
+
+// parseHeader reads one "key: value" line of a version 2 header.
+func (mf *MazeFile) parseHeader(line string) error {
+	key, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return fmt.Errorf("want \"key: value\", got %q", line)
+	}
+	value = strings.TrimSpace(value)
+
+	switch strings.TrimSpace(key) {
+	case "name":
+		mf.Name = value
+	case "movement":
+		if _, err := LookupMovement(value); err != nil {
+			return err
+		}
+		mf.Movement = value
+	case "terrain":
+		fields := strings.Fields(value)
+		if len(fields) != 3 {
+			return fmt.Errorf("want \"terrain: <symbol> <name> <cost>\", got %q", line)
+		}
+		cost, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return fmt.Errorf("bad terrain cost %q", fields[2])
+		}
+		spec := TerrainSpec{Symbol: fields[0], Name: fields[1], Cost: cost}
+		if _, err := spec.terrain(); err != nil {
+			return err
+		}
+		mf.Terrain = append(mf.Terrain, spec)
+	case "goals":
+		labels := strings.Fields(value)
+		if err := checkGoals(labels); err != nil {
+			return err
+		}
+		mf.Goals = labels
+	default:
+		return fmt.Errorf("unknown header field %q", key)
+	}
+	return nil
+}
+
+// ErrTooBig is returned when reading a maze with more cells than the maze's
+// MaxCells allows.
+var ErrTooBig = errors.New("maze is too big")
+
+// ReadJSON parses a maze in the JSON format.
+func (g *Maze) ReadJSON(r io.Reader) error {
+	var mf MazeFile
+
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&mf); err != nil {
+		return err
+	}
+
+	if mf.Version != 2 {
+		return fmt.Errorf("unsupported maze version %d (want 2)", mf.Version)
+	}
+	if mf.Movement != "" {
+		if _, err := LookupMovement(mf.Movement); err != nil {
+			return fmt.Errorf("movement: %s", err)
+		}
+	}
+	for i, spec := range mf.Terrain {
+		if _, err := spec.terrain(); err != nil {
+			return fmt.Errorf("terrain[%d]: %s", i, err)
+		}
+	}
+	if err := checkGoals(mf.Goals); err != nil {
+		return fmt.Errorf("goals: %s", err)
+	}
+
+	return g.build(mf, jsonLocator)
+}
+
+// build checks a maze file's grid and turns it into the maze, using at to
+// say where any problem is. Version 1 grids may have ragged rows, which are
+// padded out with walls; later versions must be rectangular.
+func (g *Maze) build(mf MazeFile, at locator) error {
+	if len(mf.Rows) == 0 {
+		return errors.New("maze has no rows")
+	}
+
+	legend := maps.Clone(terrains)
+	for _, spec := range mf.Terrain {
+		terrain, err := spec.terrain()
+		if err != nil {
+			return err
+		}
+		legend[terrain.Symbol] = terrain
+	}
+
+	width := 0
+	for _, row := range mf.Rows {
+		width = max(width, len([]rune(row)))
+	}
+	if g.MaxCells > 0 && len(mf.Rows)*width > g.MaxCells {
+		return fmt.Errorf("%w: %d by %d is more than %d cells", ErrTooBig, width, len(mf.Rows), g.MaxCells)
+	}
+
+	start := Point{Row: -1}
+	goals := make(map[string]Point)
+
+	rows := make([][]Wall, len(mf.Rows))
+	for i, row := range mf.Rows {
+		cells := []rune(row)
+		if mf.Version > 1 && len(cells) != width {
+			return fmt.Errorf("%s: row is %d cells wide, want %d", at(i, -1), len(cells), width)
+		}
+
+		rows[i] = make([]Wall, width)
+		for j := range width {
+			p := Point{Row: i, Col: j}
+			wall := Wall{State: p, wall: true}
+
+			if j < len(cells) {
+				switch c := cells[j]; c {
+				case '#':
+				case 'A':
+					if start.Row >= 0 {
+						return fmt.Errorf("%s: second start, the first is at %s", at(i, j), at(start.Row, start.Col))
+					}
+					start = p
+					wall.wall = false
+					wall.Terrain = legend[' ']
+				case 'B', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+					if first, ok := goals[string(c)]; ok {
+						return fmt.Errorf("%s: second goal %c, the first is at %s", at(i, j), c, at(first.Row, first.Col))
+					}
+					goals[string(c)] = p
+					wall.wall = false
+					wall.Terrain = legend[' ']
+				default:
+					terrain, ok := legend[c]
+					if !ok {
+						return fmt.Errorf("%s: unknown symbol %q", at(i, j), c)
+					}
+					wall.wall = false
+					wall.Terrain = terrain
+				}
+			}
+
+			rows[i][j] = wall
+		}
+	}
+
+	if start.Row < 0 {
+		return errors.New("starting location not found")
+	}
+
+	if len(goals) == 0 {
+		return errors.New("ending location not found")
+	}
+
+	// goals are visited in the order the file gives, or numbered goals in
+	// order then B
+	order := mf.Goals
+	if len(order) == 0 {
+		order = slices.SortedFunc(maps.Keys(goals), func(a, b string) int {
+			return goalOrder(a) - goalOrder(b)
+		})
+	}
+	for _, label := range order {
+		if _, ok := goals[label]; !ok {
+			return fmt.Errorf("goals: the maze has no goal %s", label)
+		}
+	}
+	for label := range goals {
+		if !slices.Contains(order, label) {
+			return fmt.Errorf("goals: goal %s is in the maze but not listed", label)
+		}
+	}
+
+	if mf.Movement != "" && g.Movement.Moves == nil {
+		movement, err := LookupMovement(mf.Movement)
+		if err != nil {
+			return err
+		}
+		g.Movement = movement
+	}
+
+	g.Name = mf.Name
+	g.Height = len(rows)
+	g.Width = width
+	g.Walls = rows
+	g.Start = start
+	g.Goals = nil
+	g.GoalLabels = make(map[Point]string)
+	for _, label := range order {
+		g.Goals = append(g.Goals, goals[label])
+		g.GoalLabels[goals[label]] = label
+	}
+	g.Goal = g.Goals[len(g.Goals)-1]
+
+	return nil
+}
+
+// goalOrder sorts goal labels so the digits come first, in order, then B.
+func goalOrder(label string) int {
+	if label == "B" {
+		return 10
+	}
+	return int(label[0] - '0')
+}
+
+// File returns the maze as it would be written to a version 2 or JSON file.
+func (g *Maze) File() MazeFile {
+	mf := MazeFile{
+		Version:  2,
+		Name:     g.Name,
+		Movement: g.Movement.Name,
+	}
+
+	used := make(map[rune]Terrain)
+	for _, row := range g.Walls {
+		var b strings.Builder
+		for _, cell := range row {
+			switch label, isGoal := g.GoalLabels[cell.State]; {
+			case cell.wall:
+				b.WriteByte('#')
+			case cell.State == g.Start:
+				b.WriteByte('A')
+			case isGoal:
+				b.WriteString(label)
+			default:
+				b.WriteRune(cell.Terrain.Symbol)
+				used[cell.Terrain.Symbol] = cell.Terrain
+			}
+		}
+		mf.Rows = append(mf.Rows, b.String())
+	}
+
+	for _, symbol := range slices.Sorted(maps.Keys(used)) {
+		if symbol == ' ' {
+			continue
+		}
+		t := used[symbol]
+		mf.Terrain = append(mf.Terrain, TerrainSpec{Symbol: string(symbol), Name: t.Name, Cost: t.Cost})
+	}
+
+	if len(g.Goals) > 1 {
+		for _, p := range g.Goals {
+			mf.Goals = append(mf.Goals, g.GoalLabels[p])
+		}
+	}
+
+	return mf
+}
+
+// WriteText writes the maze in the version 2 text format.
+func (g *Maze) WriteText(w io.Writer) error {
+	mf := g.File()
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, mazeV2)
+	if mf.Name != "" {
+		fmt.Fprintln(bw, "name:", mf.Name)
+	}
+	if mf.Movement != "" {
+		fmt.Fprintln(bw, "movement:", mf.Movement)
+	}
+	for _, t := range mf.Terrain {
+		fmt.Fprintln(bw, "terrain:", t.Symbol, t.Name, strconv.FormatFloat(t.Cost, 'g', -1, 64))
+	}
+	if len(mf.Goals) > 0 {
+		fmt.Fprintln(bw, "goals:", strings.Join(mf.Goals, " "))
+	}
+	fmt.Fprintln(bw, headerEnd)
+	for _, row := range mf.Rows {
+		fmt.Fprintln(bw, row)
+	}
+	return bw.Flush()
+}
+
+// WriteJSON writes the maze in the JSON format.
+func (g *Maze) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(g.File())
+}
@@ -0,0 +1,158 @@
+package maze
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestReadTerrainCost checks the costs a version 2 terrain legend accepts.
+// ParseFloat reads NaN and infinities, which would break the heuristics and
+// the frontier's ordering, so they are turned away with the costs below 1.
+func TestReadTerrainCost(t *testing.T) {
+	tests := []struct {
+		cost    string
+		wantErr string
+	}{
+		{cost: "1"},
+		{cost: "2.5"},
+		{cost: "0.5", wantErr: "less than open ground"},
+		{cost: "-3", wantErr: "less than open ground"},
+		{cost: "NaN", wantErr: "finite"},
+		{cost: "nan", wantErr: "finite"},
+		{cost: "Inf", wantErr: "finite"},
+		{cost: "+Inf", wantErr: "finite"},
+		{cost: "-Inf", wantErr: "finite"},
+		{cost: "mud", wantErr: "bad terrain cost"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cost, func(t *testing.T) {
+			text := "maze v2\nterrain: ~ mud " + tt.cost + "\n---\n#####\n#A~B#\n#####\n"
+
+			var m Maze
+			err := m.Read(strings.NewReader(text))
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Fatalf("loaded a terrain costing %s, want an error", tt.cost)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Fatalf("error %q does not mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestReadErrors checks that problems with a maze file are reported with
+// where in the file they are: the line, and the column for a cell, of a
+// text file, and the row of a JSON one.
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		json    bool
+		wantErr string
+	}{
+		{"unknown header", "maze v2\ncolour: red\n---\n#AB#\n", false, "line 2: unknown header field"},
+		{"header without colon", "maze v2\nname muddy\n---\n#AB#\n", false, "line 2: want \"key: value\""},
+		{"unknown movement", "maze v2\n\nmovement: knight\n---\n#AB#\n", false, "line 3: "},
+		{"short terrain", "maze v2\nterrain: ~ mud\n---\n#AB#\n", false, "line 2: want \"terrain:"},
+		{"reserved terrain", "maze v2\nterrain: # rock 2\n---\n#AB#\n", false, "line 2: terrain symbol \"#\" is reserved"},
+		{"goal listed twice", "maze v2\ngoals: 1 B 1\n---\n#A1B#\n", false, "line 2: goal 1 is listed twice"},
+		{"no header end", "maze v2\nname: lost\n", false, "line 2: header has no end"},
+		{"ragged row", "maze v2\n---\n#####\n#AB#\n#####\n", false, "line 4: row is 4 cells wide, want 5"},
+		{"unknown symbol", "maze v2\nname: x\n---\n#####\n#A?B#\n#####\n", false, "line 5, column 3: unknown symbol '?'"},
+		{"second start", "maze v2\n---\n#AAB#\n", false, "line 3, column 3: second start, the first is at line 3, column 2"},
+		{"second goal", "#A  #\n# B B\n", false, "line 2, column 5: second goal B, the first is at line 2, column 3"},
+		{"unlisted goal", "maze v2\ngoals: B\n---\n#A1B#\n", false, "goals: goal 1 is in the maze but not listed"},
+		{"missing goal", "maze v2\ngoals: 2 B\n---\n#A1B#\n", false, "goals: the maze has no goal 2"},
+		{"no start", "#  B#\n", false, "starting location not found"},
+		{"no goal", "#A  #\n", false, "ending location not found"},
+		{"json version", `{"version": 1, "rows": ["#AB#"]}`, true, "unsupported maze version 1"},
+		{"json unknown field", `{"version": 2, "colour": "red", "rows": ["#AB#"]}`, true, "unknown field \"colour\""},
+		{"json terrain", `{"version": 2, "terrain": [{"symbol": "~", "name": "mud", "cost": 0.5}], "rows": ["#AB#"]}`, true, "terrain[0]: terrain mud costs 0.5"},
+		{"json ragged row", `{"version": 2, "rows": ["####", "#AB", "####"]}`, true, "rows[1]: row is 3 cells wide, want 4"},
+		{"json unknown symbol", `{"version": 2, "rows": ["#####", "#A?B#"]}`, true, "rows[1], column 3: unknown symbol '?'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Maze
+			var err error
+			if tt.json {
+				err = m.ReadJSON(strings.NewReader(tt.text))
+			} else {
+				err = m.Read(strings.NewReader(tt.text))
+			}
+
+			if err == nil {
+				t.Fatalf("read the maze, want an error containing %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %q does not contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadTooBig(t *testing.T) {
+	m := Maze{MaxCells: 10}
+	err := m.Read(strings.NewReader("######\n#A  B#\n######\n"))
+	if !errors.Is(err, ErrTooBig) {
+		t.Errorf("error %v, want ErrTooBig", err)
+	}
+}
+
+// TestRoundTrip writes mazes out as text and JSON and reads them back,
+// which should give the same maze file each time.
+func TestRoundTrip(t *testing.T) {
+	mazes := []struct {
+		name string
+		text string
+	}{
+		{"version 1", "##B   #\n## ## #\n#  #  #\n# ## ##\n     ##\nA######\n"},
+		{"flooded", "#####\n#AwB#\n#####\n"},
+		{"version 2", "maze v2\nname: muddy corridor\nmovement: grid8\nterrain: ~ mud 5\nterrain: , sand 1.5\ngoals: 2 1 B\n---\n#######\n#A~1,2#\n#~~B###\n#######\n"},
+		{"hex", "maze v2\nmovement: hex\n---\n#####\n#A  #\n#  B#\n#####\n"},
+	}
+
+	for _, tt := range mazes {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Maze
+			if err := m.Read(strings.NewReader(tt.text)); err != nil {
+				t.Fatal(err)
+			}
+			want := m.File()
+
+			var text bytes.Buffer
+			if err := m.WriteText(&text); err != nil {
+				t.Fatal(err)
+			}
+			var fromText Maze
+			if err := fromText.Read(&text); err != nil {
+				t.Fatalf("reading back the text: %v", err)
+			}
+			if got := fromText.File(); !reflect.DeepEqual(got, want) {
+				t.Errorf("text round trip gave\n%+v\nwant\n%+v", got, want)
+			}
+
+			var js bytes.Buffer
+			if err := m.WriteJSON(&js); err != nil {
+				t.Fatal(err)
+			}
+			var fromJSON Maze
+			if err := fromJSON.ReadJSON(&js); err != nil {
+				t.Fatalf("reading back the json: %v", err)
+			}
+			if got := fromJSON.File(); !reflect.DeepEqual(got, want) {
+				t.Errorf("json round trip gave\n%+v\nwant\n%+v", got, want)
+			}
+			if fromJSON.Start != m.Start || !reflect.DeepEqual(fromJSON.Goals, m.Goals) {
+				t.Errorf("json round trip moved the start or goals: %v %v, want %v %v", fromJSON.Start, fromJSON.Goals, m.Start, m.Goals)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package maze
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Move is a single step a movement model allows, as an offset from the
+// current cell. Length scales the terrain cost of the cell moved into.
+type Move struct {
+	Action string
+	Row    int
+	Col    int
+	Length float64
+}
+
+// Movement describes how an agent can move around the maze: which moves are
+// possible from a cell, and an admissible heuristic estimating the cost of
+// the cheapest path between two cells.
+type Movement struct {
+	Name      string
+	Diagonal  bool // true if moves can leave a cell other than through its sides
+	Hex       bool // true if odd rows are offset by half a cell
+	Moves     func(p Point) []Move
+	Heuristic func(a, b Point) float64
+}
+
+var (
+	orthogonalMoves = []Move{
+		{Action: "up", Row: -1, Length: 1},
+		{Action: "down", Row: 1, Length: 1},
+		{Action: "left", Col: -1, Length: 1},
+		{Action: "right", Col: 1, Length: 1},
+	}
+
+	diagonalMoves = append(slices.Clone(orthogonalMoves), []Move{
+		{Action: "up-left", Row: -1, Col: -1, Length: math.Sqrt2},
+		{Action: "up-right", Row: -1, Col: 1, Length: math.Sqrt2},
+		{Action: "down-left", Row: 1, Col: -1, Length: math.Sqrt2},
+		{Action: "down-right", Row: 1, Col: 1, Length: math.Sqrt2},
+	}...)
+
+	// hex grids use "odd-r" offset coordinates, where odd rows are pushed
+	// half a cell to the right, so the neighbours above and below depend on
+	// whether the row is odd or even
+	hexEvenRowMoves = []Move{
+		{Action: "northwest", Row: -1, Col: -1, Length: 1},
+		{Action: "northeast", Row: -1, Col: 0, Length: 1},
+		{Action: "west", Col: -1, Length: 1},
+		{Action: "east", Col: 1, Length: 1},
+		{Action: "southwest", Row: 1, Col: -1, Length: 1},
+		{Action: "southeast", Row: 1, Col: 0, Length: 1},
+	}
+	hexOddRowMoves = []Move{
+		{Action: "northwest", Row: -1, Col: 0, Length: 1},
+		{Action: "northeast", Row: -1, Col: 1, Length: 1},
+		{Action: "west", Col: -1, Length: 1},
+		{Action: "east", Col: 1, Length: 1},
+		{Action: "southwest", Row: 1, Col: 0, Length: 1},
+		{Action: "southeast", Row: 1, Col: 1, Length: 1},
+	}
+)
+
+// movements holds the movement models that can be selected with -movement.
+var movements = map[string]Movement{
+	"grid4": {
+		Name:      "grid4",
+		Moves:     func(Point) []Move { return orthogonalMoves },
+		Heuristic: manhattanDistance,
+	},
+	"grid8": {
+		Name:      "grid8",
+		Diagonal:  true,
+		Moves:     func(Point) []Move { return diagonalMoves },
+		Heuristic: octileDistance,
+	},
+	"hex": {
+		Name:     "hex",
+		Diagonal: true,
+		Hex:      true,
+		Moves: func(p Point) []Move {
+			if p.Row%2 == 1 {
+				return hexOddRowMoves
+			}
+			return hexEvenRowMoves
+		},
+		Heuristic: hexDistance,
+	},
+}
+
+// LookupMovement returns the movement model registered under name.
+func LookupMovement(name string) (Movement, error) {
+	m, ok := movements[name]
+	if !ok {
+		return Movement{}, fmt.Errorf("invalid movement %q, must be one of: %s", name, strings.Join(MovementNames(), ", "))
+	}
+	return m, nil
+}
+
+// MovementNames returns the names of all movement models, sorted.
+func MovementNames() []string {
+	var names []string
+	for name := range movements {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// movement returns the maze's movement model, defaulting to the four
+// orthogonal moves.
+func (g *Maze) movement() Movement {
+	if g.Movement.Moves == nil {
+		return movements["grid4"]
+	}
+	return g.Movement
+}
+
+// Heuristic estimates the cost of the cheapest path from p to the goal, or
+// to the nearest goal when searching for several. It assumes no terrain is
+// cheaper than open ground, so it never overestimates.
+func (g *Maze) Heuristic(p Point) float64 {
+	h := math.Inf(1)
+	for _, goal := range g.goals() {
+		h = min(h, g.movement().Heuristic(p, goal))
+	}
+	return h
+}
+
+// open reports whether p is inside the maze and not a wall.
+func (g *Maze) open(p Point) bool {
+	return p.Row >= 0 && p.Row < len(g.Walls) && p.Col >= 0 && p.Col < len(g.Walls[p.Row]) && !g.Walls[p.Row][p.Col].wall
+}
+
+// allowed reports whether move can be taken from p. Diagonal moves on a
+// square grid may not cut the corner of a wall, so both cells beside the
+// diagonal must be open too.
+func (g *Maze) allowed(p Point, move Move) bool {
+	to := Point{Row: p.Row + move.Row, Col: p.Col + move.Col}
+	if !g.open(to) {
+		return false
+	}
+
+	if !g.movement().Hex && move.Row != 0 && move.Col != 0 {
+		return g.open(Point{Row: p.Row + move.Row, Col: p.Col}) && g.open(Point{Row: p.Row, Col: p.Col + move.Col})
+	}
+	return true
+}
+
+// moveCost returns the cost of moving from a cell to an adjacent one.
+func (g *Maze) moveCost(from, to Point) float64 {
+	cost := g.StepCost(to)
+	if !g.movement().Hex && from.Row != to.Row && from.Col != to.Col {
+		cost *= math.Sqrt2
+	}
+	return cost
+}
+
+func manhattanDistance(a, b Point) float64 {
+	return float64(abs(a.Row-b.Row) + abs(a.Col-b.Col))
+}
+
+// octileDistance is the length of the shortest 8-connected path on an open
+// grid: diagonal moves as far as possible, then straight moves.
+func octileDistance(a, b Point) float64 {
+	dr, dc := abs(a.Row-b.Row), abs(a.Col-b.Col)
+	return float64(max(dr, dc)) + (math.Sqrt2-1)*float64(min(dr, dc))
+}
+
+// hexDistance is the number of moves between two cells of an odd-r hex
+// grid, found by converting them to cube coordinates.
+func hexDistance(a, b Point) float64 {
+	ax, az := a.Col-(a.Row-(a.Row&1))/2, a.Row
+	bx, bz := b.Col-(b.Row-(b.Row&1))/2, b.Row
+	dx, dz := ax-bx, az-bz
+	dy := -dx - dz
+	return float64(max(abs(dx), abs(dy), abs(dz)))
+}
@@ -0,0 +1,49 @@
+package maze
+
+// PointSet is a set of maze cells backed by a bitset, one bit per cell, so
+// adding and testing membership are constant time regardless of maze size.
+type PointSet struct {
+	height int
+	width  int
+	bits   []uint64
+}
+
+// NewPointSet returns an empty set able to hold every cell of a
+// height x width maze.
+func NewPointSet(height, width int) *PointSet {
+	return &PointSet{
+		height: height,
+		width:  width,
+		bits:   make([]uint64, (height*width+63)/64),
+	}
+}
+
+func (s *PointSet) offset(p Point) (int, bool) {
+	if p.Row < 0 || p.Row >= s.height || p.Col < 0 || p.Col >= s.width {
+		return 0, false
+	}
+	return p.Row*s.width + p.Col, true
+}
+
+// Add puts p in the set. Points outside the maze are ignored.
+func (s *PointSet) Add(p Point) {
+	if i, ok := s.offset(p); ok {
+		s.bits[i/64] |= 1 << (i % 64)
+	}
+}
+
+// Remove takes p out of the set.
+func (s *PointSet) Remove(p Point) {
+	if i, ok := s.offset(p); ok {
+		s.bits[i/64] &^= 1 << (i % 64)
+	}
+}
+
+// Has reports whether p is in the set. A nil set is empty.
+func (s *PointSet) Has(p Point) bool {
+	if s == nil {
+		return false
+	}
+	i, ok := s.offset(p)
+	return ok && s.bits[i/64]&(1<<(i%64)) != 0
+}
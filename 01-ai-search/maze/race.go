@@ -0,0 +1,70 @@
+package maze
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// RaceEntry is how one strategy did in a race.
+type RaceEntry struct {
+	Result
+	Err error // why the run failed, including a solution that failed Check
+}
+
+// RaceResult is the outcome of a race between strategies.
+type RaceResult struct {
+	Winner  string      // name of the winning strategy, or empty if none finished
+	Maze    *Maze       // the fork the winner searched, holding its State for drawing
+	Entries []RaceEntry // how every strategy did, in the order they were given
+}
+
+// Race solves the maze with several strategies at once, each on its own
+// Fork, so the maze itself is only read. The first to finish wins, provided
+// its solution passes Check or it shows there is no solution at all, and the
+// rest are cancelled. Race waits for every strategy to stop before
+// returning.
+func Race(ctx context.Context, m *Maze, racers []Strategy) (RaceResult, error) {
+	if len(racers) == 0 {
+		return RaceResult{}, errors.New("no strategies to race")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type finish struct {
+		i      int
+		fork   *Maze
+		result Result
+		err    error
+	}
+
+	done := make(chan finish, len(racers))
+	for i, strategy := range racers {
+		fork := m.Fork()
+		go func() {
+			result, err := Solve(ctx, fork, strategy)
+			if err == nil && result.Status == Solved {
+				if err = fork.Check(result.Solution); err != nil {
+					err = fmt.Errorf("solution failed its check: %w", err)
+				}
+			}
+			done <- finish{i: i, fork: fork, result: result, err: err}
+		}()
+	}
+
+	race := RaceResult{Entries: make([]RaceEntry, len(racers))}
+	for range racers {
+		f := <-done
+		f.result.Search = racers[f.i].Name
+		race.Entries[f.i] = RaceEntry{Result: f.result, Err: f.err}
+
+		finished := f.result.Status == Solved || f.result.Status == NoSolution
+		if race.Winner == "" && f.err == nil && finished {
+			race.Winner, race.Maze = racers[f.i].Name, f.fork
+			cancel()
+		}
+	}
+
+	return race, nil
+}
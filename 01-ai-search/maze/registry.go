@@ -0,0 +1,133 @@
+package maze
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Solver is anything that can solve a maze, filling in its Solution,
+// Explored and NumExplored. A solver stopped by its context or the maze's
+// Limits returns the reason, leaving the path to the closest cell it reached
+// as the Solution.
+type Solver interface {
+	Solve(ctx context.Context) error
+}
+
+// Strategy describes a search algorithm that can be selected with -search.
+// Most algorithms only differ in the order they expand nodes and just supply
+// a NewFrontier for the generic Search engine; algorithms that need their
+// own search loop supply New instead.
+type Strategy struct {
+	Name        string
+	Description string
+	SearchType  int
+	NewFrontier func(m *Maze) Frontier
+	New         func(m *Maze) Solver
+}
+
+// strategies holds every registered search algorithm, keyed by the name used
+// on the command line. Adding an algorithm only requires a frontier
+// constructor and an entry here.
+var strategies = map[string]Strategy{
+	"dfs": {
+		Name:        "dfs",
+		Description: "depth first search",
+		SearchType:  DFS,
+		NewFrontier: newDepthFirstFrontier,
+	},
+	"bfs": {
+		Name:        "bfs",
+		Description: "breadth first search",
+		SearchType:  BFS,
+		NewFrontier: newBreadthFirstFrontier,
+	},
+	"gbfs": {
+		Name:        "gbfs",
+		Description: "greedy best first search",
+		SearchType:  GBFS,
+		NewFrontier: newGreedyBestFirstFrontier,
+	},
+	"astar": {
+		Name:        "astar",
+		Description: "A* search",
+		SearchType:  ASTAR,
+		NewFrontier: newAStarFrontier,
+	},
+	"dijkstra": {
+		Name:        "dijkstra",
+		Description: "dijkstra search",
+		SearchType:  DIJKSTRA,
+		NewFrontier: newDijkstraFrontier,
+	},
+	"bibfs": {
+		Name:        "bibfs",
+		Description: "bidirectional breadth first search",
+		SearchType:  BIBFS,
+		New: func(m *Maze) Solver {
+			return &BidirectionalSearch{Game: m}
+		},
+	},
+	"iddfs": {
+		Name:        "iddfs",
+		Description: "iterative deepening depth first search",
+		SearchType:  IDDFS,
+		New:         newIterativeDeepeningDFS,
+	},
+	"idastar": {
+		Name:        "idastar",
+		Description: "IDA* search",
+		SearchType:  IDASTAR,
+		New:         newIDAStar,
+	},
+	"jps": {
+		Name:        "jps",
+		Description: "jump point search",
+		SearchType:  JPS,
+		New:         newJumpPointSearch,
+	},
+	"dstar": {
+		Name:        "dstar",
+		Description: "D* Lite search",
+		SearchType:  DSTAR,
+		New:         newDStarLite,
+	},
+}
+
+// LookupStrategy returns the strategy registered under name.
+func LookupStrategy(name string) (Strategy, error) {
+	s, ok := strategies[name]
+	if !ok {
+		return Strategy{}, fmt.Errorf("invalid search type %q, must be one of: %s", name, strings.Join(StrategyNames(), ", "))
+	}
+	return s, nil
+}
+
+// StrategyNames returns the names of all registered strategies, sorted.
+func StrategyNames() []string {
+	var names []string
+	for name := range strategies {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// NewSolver builds a solver for the given maze using this strategy.
+func (s Strategy) NewSolver(m *Maze) Solver {
+	if s.New != nil {
+		return s.New(m)
+	}
+	return s.NewSearch(m)
+}
+
+// NewSearch builds a generic search engine for the given maze using this
+// strategy's frontier.
+func (s Strategy) NewSearch(m *Maze) *Search {
+	return &Search{
+		Name:     s.Description,
+		Frontier: s.NewFrontier(m),
+		Game:     m,
+	}
+}
@@ -0,0 +1,314 @@
+package maze
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DefaultCellSize is the size of a cell, in pixels, when none is given.
+const DefaultCellSize = 60
+
+// View holds the options for drawing a maze, which every renderer follows.
+type View struct {
+	Format   string // renderer to use; empty picks one from the file name
+	CellSize int    // pixels per cell, for the image renderers
+	Order    bool   // number explored cells in the order they were expanded
+	Frontier bool   // shade the cells left on the frontier when the search stopped
+	Costs    bool   // show f, g and h for every cell
+}
+
+// overlays are the extra layers of information a View can show.
+var overlays = map[string]func(v *View){
+	"order":    func(v *View) { v.Order = true },
+	"frontier": func(v *View) { v.Frontier = true },
+	"costs":    func(v *View) { v.Costs = true },
+}
+
+// SetOverlays turns on the overlays named in a comma separated list.
+func (v *View) SetOverlays(list string) error {
+	for name := range strings.SplitSeq(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		set, ok := overlays[name]
+		if !ok {
+			return fmt.Errorf("invalid overlay %q, must be one of: order, frontier, costs", name)
+		}
+		set(v)
+	}
+	return nil
+}
+
+// Renderer draws a maze in its current state.
+type Renderer interface {
+	Render(w io.Writer, g *Maze) error
+}
+
+// renderers holds every way of drawing a maze, keyed by the name used with
+// -render.
+var renderers = map[string]Renderer{
+	"png":  pngRenderer{},
+	"svg":  svgRenderer{},
+	"ansi": ansiRenderer{},
+}
+
+// LookupRenderer returns the renderer registered under name.
+func LookupRenderer(name string) (Renderer, error) {
+	r, ok := renderers[name]
+	if !ok {
+		return nil, fmt.Errorf("invalid renderer %q, must be one of: %s", name, strings.Join(RendererNames(), ", "))
+	}
+	return r, nil
+}
+
+// RendererNames returns the names of all renderers, sorted.
+func RendererNames() []string {
+	var names []string
+	for name := range renderers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// RendererFor returns the name of the renderer to draw filename with: the
+// one the View names, or else one picked from the file's extension. A
+// filename of "-" stands for the terminal.
+func (v View) RendererFor(filename string) string {
+	if v.Format != "" {
+		return v.Format
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".svg":
+		return "svg"
+	case ".txt", ".ans":
+		return "ansi"
+	}
+	if filename == "-" {
+		return "ansi"
+	}
+	return "png"
+}
+
+// cellSize returns the size of a cell in pixels.
+func (g *Maze) cellSize() int {
+	if g.View.CellSize > 0 {
+		return g.View.CellSize
+	}
+	return DefaultCellSize
+}
+
+// imageSize returns the size in pixels of the whole maze.
+func (g *Maze) imageSize() image.Point {
+	size := g.cellSize()
+	width := size * g.Width
+
+	// odd rows of a hex grid stick out by half a cell
+	if g.movement().Hex {
+		width += size / 2
+	}
+
+	return image.Point{X: width, Y: size * g.Height}
+}
+
+// cellBounds returns the area of the image covered by the cell at p.
+func (g *Maze) cellBounds(p Point) image.Rectangle {
+	size := g.cellSize()
+	x, y := p.Col*size, p.Row*size
+	if g.movement().Hex && p.Row%2 == 1 {
+		x += size / 2
+	}
+	return image.Rect(x, y, x+size, y+size)
+}
+
+// cellColour returns the colour to draw the cell at p.
+func (g *Maze) cellColour(p Point) color.RGBA {
+	col := g.Walls[p.Row][p.Col]
+	_, isGoal := g.GoalLabels[p]
+
+	switch {
+	case col.wall:
+		return black
+	case p == g.Start:
+		// starting point
+		return darkGreen
+	case isGoal || p == g.Goal:
+		// ending point or waypoint
+		return red
+	case g.inSolution(p):
+		return green
+	case g.CurrentNode != nil && p == g.CurrentNode.State:
+		// current location
+		return orange
+	case col.Water():
+		return blue
+	case g.inExplored(p):
+		return yellow
+	case g.View.Frontier && g.inFrontier(p):
+		return lavender
+	default:
+		return white
+	}
+}
+
+// cellLabel is a piece of text drawn inside a cell.
+type cellLabel struct {
+	Text   string
+	X, Y   float64 // where the text's baseline starts, as a fraction of the cell
+	Right  bool    // X is where the text ends rather than where it starts
+	Colour color.RGBA
+}
+
+// cellLabels returns the text to draw inside the cell at p: its costs, its
+// coordinates, what kind of terrain it is and any overlays.
+func (g *Maze) cellLabels(p Point) []cellLabel {
+	col := g.Walls[p.Row][p.Col]
+	if col.wall {
+		return nil
+	}
+
+	var labels []cellLabel
+	add := func(text string, x, y float64, right bool, c color.RGBA) {
+		if text != "" {
+			labels = append(labels, cellLabel{Text: text, X: x, Y: y, Right: right, Colour: c})
+		}
+	}
+
+	// costs along the top, terrain and order in the top right corner
+	add(g.costLabel(p), 0.1, 0.29, false, black)
+
+	var corner []string
+	if col.Terrain.Symbol != ' ' && col.Terrain.Symbol != 0 {
+		corner = append(corner, strings.ToUpper(string(col.Terrain.Symbol)))
+	}
+	if i := g.exploredOrder(p); g.View.Order && i > 0 {
+		corner = append(corner, fmt.Sprint(i))
+	}
+	cornerColour := black
+	if col.Water() {
+		cornerColour = blue
+	}
+	add(strings.Join(corner, " "), 0.93, 0.3, true, cornerColour)
+
+	// the x y coordinates of this cell
+	add(fmt.Sprintf("[%d %d]", p.Row, p.Col), 0.1, 0.67, false, black)
+
+	// g and h along the bottom
+	if g.View.Costs {
+		h := "h=" + FormatCost(g.Heuristic(p))
+		if cost, ok := g.PathCosts[p]; ok {
+			h = "g=" + FormatCost(cost) + " " + h
+		}
+		add(h, 0.1, 0.9, false, black)
+	}
+
+	// label goals when there are several of them
+	if label, ok := g.GoalLabels[p]; ok && len(g.Goals) > 1 {
+		add(label, 0.93, 0.9, true, black)
+	}
+
+	return labels
+}
+
+// costLabel is the cost shown at the top of a cell, which depends on the
+// search used unless every cost is being shown.
+func (g *Maze) costLabel(p Point) string {
+	cost, reached := g.PathCosts[p]
+	toGoal := g.Heuristic(p)
+
+	if g.View.Costs {
+		if reached {
+			return "f=" + FormatCost(cost+toGoal)
+		}
+		return ""
+	}
+
+	switch g.SearchType {
+	case DIJKSTRA, DSTAR:
+		// path cost from the start, or to the goal for D* Lite, once the
+		// cell has been expanded
+		if reached {
+			return FormatCost(cost)
+		}
+	case GBFS:
+		return FormatCost(toGoal)
+	case ASTAR, IDASTAR, JPS:
+		// f = g + h, once the cell has been expanded; otherwise just h
+		if reached {
+			return FormatCost(cost + toGoal)
+		}
+		return "h=" + FormatCost(toGoal)
+	}
+	return ""
+}
+
+// exploredOrder returns when p was expanded, counting from 1, or 0 if it
+// never was.
+func (g *Maze) exploredOrder(p Point) int {
+	if !g.inExplored(p) {
+		return 0
+	}
+	if g.exploredIndex == nil || len(g.exploredIndex) != len(g.Explored) {
+		g.exploredIndex = make(map[Point]int, len(g.Explored))
+		for i, q := range g.Explored {
+			g.exploredIndex[q] = i + 1
+		}
+	}
+	return g.exploredIndex[p]
+}
+
+// showPath reports whether the solution needs drawing as a line. With
+// diagonal moves, neighbouring solution cells don't show which way the path
+// went, and a route through several goals may double back on itself.
+func (g *Maze) showPath() bool {
+	return g.movement().Diagonal || len(g.Solution.Legs) > 1
+}
+
+// pathLegs splits the solution into the stretches drawn in each colour,
+// each starting where the last one ended.
+func (g *Maze) pathLegs() [][]Point {
+	var legs [][]Point
+	from := g.Start
+	cells := g.Solution.Cells
+
+	steps := []int{len(cells)}
+	if len(g.Solution.Legs) > 0 {
+		steps = nil
+		for _, leg := range g.Solution.Legs {
+			steps = append(steps, leg.Steps)
+		}
+	}
+
+	for _, n := range steps {
+		n = min(n, len(cells))
+		leg := append([]Point{from}, cells[:n]...)
+		legs = append(legs, leg)
+		if n > 0 {
+			from = cells[n-1]
+		}
+		cells = cells[n:]
+	}
+	return legs
+}
+
+// inFrontier reports whether p was waiting on the frontier when the search
+// stopped.
+func (g *Maze) inFrontier(p Point) bool {
+	return g.frontier.Has(p)
+}
+
+// recordFrontier remembers the nodes left on the frontier, so they can be
+// drawn.
+func (g *Maze) recordFrontier(nodes []*Node) {
+	g.frontier = NewPointSet(g.Height, g.Width)
+	for _, n := range nodes {
+		g.frontier.Add(n.State)
+	}
+}
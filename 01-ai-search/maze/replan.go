@@ -0,0 +1,287 @@
+package maze
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Event is a scripted change to the maze, which happens once the agent has
+// made Step moves.
+type Event struct {
+	Step   int
+	Action string
+	At     Point
+	line   int
+}
+
+// eventActions are the changes an event can make to a cell.
+var eventActions = map[string]func(w *Wall){
+	"wall": func(w *Wall) {
+		w.wall = true
+		w.Terrain = Terrain{}
+	},
+	"open": func(w *Wall) {
+		w.wall = false
+		w.Terrain = terrains[' ']
+	},
+	"water": func(w *Wall) {
+		w.wall = false
+		w.Terrain = terrains['w']
+	},
+}
+
+// ReadEvents parses an events file. Each line is the number of moves after
+// which the event happens, what happens (wall, open or water) and the row
+// and column of the cell it happens to:
+//
+//	# a wall appears across the corridor after five moves
+//	5 wall 3 4
+//	12 water 6 2
+//
+// Blank lines and lines starting with # are ignored. Events are returned in
+// the order they happen.
+func ReadEvents(r io.Reader) ([]Event, error) {
+	var events []Event
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("line %d: want \"<step> <action> <row> <col>\", got %q", line, text)
+		}
+
+		var numbers [3]int
+		for i, field := range []string{fields[0], fields[2], fields[3]} {
+			n, err := strconv.Atoi(field)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("line %d: %q is not a whole number", line, field)
+			}
+			numbers[i] = n
+		}
+
+		if _, ok := eventActions[fields[1]]; !ok {
+			return nil, fmt.Errorf("line %d: unknown action %q (want wall, open or water)", line, fields[1])
+		}
+
+		events = append(events, Event{
+			Step:   numbers[0],
+			Action: fields[1],
+			At:     Point{Row: numbers[1], Col: numbers[2]},
+			line:   line,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(events, func(a, b Event) int {
+		return a.Step - b.Step
+	})
+	return events, nil
+}
+
+// LoadEvents reads an events file.
+func LoadEvents(filename string) ([]Event, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	events, err := ReadEvents(f)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read events %s: %s", filename, err)
+	}
+	return events, nil
+}
+
+// Replan is the record of one plan made while walking the maze.
+type Replan struct {
+	Step      int
+	At        Point
+	Changes   int
+	Expanded  int // cells D* Lite expanded
+	FromStart int // cells A* expanded planning again from scratch
+	Cost      float64
+	Reachable bool
+}
+
+// Walk moves an agent from the start to the goal along the path planned by
+// D* Lite, applying events as it goes and replanning whenever they change
+// the maze. It returns every plan made; the path walked becomes the maze's
+// solution. The maze's node budget covers every plan together, and a plan
+// stopped by it or by ctx ends the walk with that error.
+func (g *Maze) Walk(ctx context.Context, events []Event, seed int64) ([]Replan, error) {
+	for _, e := range events {
+		if !g.inside(e.At) {
+			return nil, fmt.Errorf("event on line %d: %v is outside the maze", e.line, e.At)
+		}
+	}
+
+	d := &DStarLite{Game: g}
+	g.resetSearch()
+
+	var plans []Replan
+	var walked Solution
+	agent, step, next := g.Start, 0, 0
+
+	for {
+		// apply any events due by now
+		var changed []Point
+		for ; next < len(events) && events[next].Step <= step; next++ {
+			if p, ok := g.apply(events[next], agent); ok {
+				changed = append(changed, p)
+			}
+		}
+
+		if step == 0 || len(changed) > 0 {
+			g.clearExplored()
+			before := g.NumExplored
+			if step == 0 {
+				d.Init(agent)
+			} else {
+				d.Changed(changed)
+			}
+			if _, err := d.Plan(ctx); err != nil {
+				return plans, err
+			}
+
+			fromStart, err := g.fromScratch(ctx, agent, seed)
+			if err != nil {
+				return nil, err
+			}
+
+			plan := Replan{
+				Step:      step,
+				At:        agent,
+				Changes:   len(changed),
+				Expanded:  g.NumExplored - before,
+				FromStart: fromStart,
+				Reachable: d.Reachable(),
+			}
+			if plan.Reachable {
+				plan.Cost = d.Path().Cost
+			}
+			plans = append(plans, plan)
+
+			g.moveAgent(agent)
+			g.showPlan(walked, d.Path())
+			g.frame()
+		}
+
+		if g.isGoal(agent) || !d.Reachable() {
+			break
+		}
+
+		move, to, _ := d.Next(agent)
+		walked.Actions = append(walked.Actions, move.Action)
+		walked.Cells = append(walked.Cells, to)
+		walked.Cost += g.StepCost(to) * move.Length
+		agent = to
+		step++
+
+		d.MoveTo(agent)
+		g.moveAgent(agent)
+		g.frame()
+	}
+
+	if g.isGoal(agent) {
+		g.setSolution(walked)
+	} else {
+		g.setSolution(Solution{})
+	}
+	return plans, nil
+}
+
+// inside reports whether p is a cell of the maze.
+func (g *Maze) inside(p Point) bool {
+	return p.Row >= 0 && p.Row < g.Height && p.Col >= 0 && p.Col < g.Width
+}
+
+// apply makes the change an event describes, reporting the cell changed. The
+// agent and the goal can't be walled in, so those events are skipped.
+func (g *Maze) apply(e Event, agent Point) (Point, bool) {
+	if e.Action == "wall" && (e.At == agent || g.isGoal(e.At)) {
+		g.reportf(Notice, "skipping event on line %d: can't put a wall on %v", e.line, e.At)
+		return e.At, false
+	}
+
+	cell := &g.Walls[e.At.Row][e.At.Col]
+	before := *cell
+	eventActions[e.Action](cell)
+	if *cell == before {
+		return e.At, false
+	}
+
+	if g.Animation != nil {
+		g.Animation.MarkDirty(e.At)
+	}
+	return e.At, true
+}
+
+// fromScratch returns how many cells A* expands planning from p to the goal
+// without reusing anything, on a quiet copy of the maze.
+func (g *Maze) fromScratch(ctx context.Context, p Point, seed int64) (int, error) {
+	probe := g.Fork()
+	probe.Progress = nil
+	probe.Limits = Limits{}
+	probe.Start = p
+	probe.SetSeed(seed)
+	err := strategies["astar"].NewSolver(probe).Solve(ctx)
+	return probe.NumExplored, err
+}
+
+// clearExplored forgets which cells have been explored, so the next plan is
+// drawn on its own. The count of expanded cells carries on.
+func (g *Maze) clearExplored() {
+	if g.Animation != nil {
+		for _, p := range g.Explored {
+			g.Animation.MarkDirty(p)
+		}
+	}
+	g.Explored = nil
+	g.explored = NewPointSet(g.Height, g.Width)
+	g.exploredIndex = nil
+	g.PathCosts = make(map[Point]float64)
+}
+
+// moveAgent shows the agent at p.
+func (g *Maze) moveAgent(p Point) {
+	if g.Animation != nil {
+		if g.CurrentNode != nil {
+			g.Animation.MarkDirty(g.CurrentNode.State)
+		}
+		g.Animation.MarkDirty(p)
+	}
+	g.CurrentNode = &Node{State: p}
+}
+
+// showPlan shows the path walked so far followed by the path still planned.
+func (g *Maze) showPlan(walked, planned Solution) {
+	if g.Animation != nil {
+		for _, p := range g.Solution.Cells {
+			g.Animation.MarkDirty(p)
+		}
+	}
+
+	g.setSolution(Solution{
+		Cells: append(slices.Clone(walked.Cells), planned.Cells...),
+	})
+
+	if g.Animation != nil {
+		for _, p := range g.Solution.Cells {
+			g.Animation.MarkDirty(p)
+		}
+	}
+}
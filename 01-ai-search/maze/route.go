@@ -0,0 +1,308 @@
+package maze
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+// Leg is one stretch of a route through several goals, from the start or a
+// waypoint to the next goal visited.
+type Leg struct {
+	From  Point
+	To    Point
+	Steps int
+	Cost  float64
+}
+
+// routes are the ways a maze with more than one goal can be solved.
+var routes = map[string]string{
+	"waypoints": "visit the goals in turn, in label order or the order given by -waypoints",
+	"nearest":   "stop at whichever goal is cheapest to reach",
+	"all":       "visit every goal in the cheapest order, finishing at B if there is one",
+}
+
+// RouteNames returns the names of every route, sorted.
+func RouteNames() []string {
+	var names []string
+	for name := range routes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Route solves the maze with strategy, visiting its goals as the named route
+// describes. For waypoints, order lists the goal labels to visit; when it is
+// empty every goal is visited, numbered goals first and B last. If the
+// search is stopped early, the solution is the route as far as it got.
+func (g *Maze) Route(ctx context.Context, strategy Strategy, route string, order []string) error {
+	var err error
+	switch route {
+	case "waypoints":
+		var stops []Point
+		if stops, err = g.stops(order); err != nil {
+			return err
+		}
+		err = g.solveLegs(ctx, strategy, stops)
+		g.Solution.Waypoints = stops
+	case "nearest":
+		err = g.solveNearest(ctx, strategy)
+	case "all":
+		var stops []Point
+		if stops, err = g.tour(ctx); err != nil {
+			return err
+		}
+		err = g.solveLegs(ctx, strategy, stops)
+	default:
+		return fmt.Errorf("unknown route %q (want one of %s)", route, strings.Join(RouteNames(), ", "))
+	}
+	g.Solution.Route = route
+	return err
+}
+
+// stops looks up the goals to visit for a waypoints route.
+func (g *Maze) stops(order []string) ([]Point, error) {
+	if len(order) == 0 {
+		return g.Goals, nil
+	}
+
+	byLabel := make(map[string]Point)
+	for p, label := range g.GoalLabels {
+		byLabel[label] = p
+	}
+
+	var stops []Point
+	for _, label := range order {
+		p, ok := byLabel[strings.TrimSpace(label)]
+		if !ok {
+			return nil, fmt.Errorf("maze has no goal labelled %q", label)
+		}
+		stops = append(stops, p)
+	}
+	return stops, nil
+}
+
+// solveLegs runs one search per leg, from each stop to the next, and joins
+// the results into a single solution. Explored cells and statistics are
+// combined across every leg. A leg stopped early ends the route with the
+// path to the closest cell it reached.
+func (g *Maze) solveLegs(ctx context.Context, strategy Strategy, stops []Point) error {
+	start, goal := g.Start, g.Goal
+	defer func() {
+		g.Start, g.Goal = start, goal
+	}()
+
+	var route Solution
+	var explored []Point
+	var tree []expansion
+	pathCosts := make(map[Point]float64)
+	numExplored, maxFrontier := 0, 0
+	solved := true
+
+	// a single leg needs nothing combining, which matters on big mazes
+	combine := len(stops) > 1
+
+	var err error
+	for i, stop := range stops {
+		if i > 0 {
+			if err = ctx.Err(); err != nil {
+				break
+			}
+		}
+
+		g.Goal = stop
+		err = strategy.NewSolver(g).Solve(ctx)
+
+		if combine {
+			// path costs are shown from the start of the whole route
+			for _, p := range g.Explored {
+				if _, ok := pathCosts[p]; !ok {
+					pathCosts[p] = route.Cost + g.PathCosts[p]
+				}
+			}
+			explored = append(explored, g.Explored...)
+			for _, e := range g.tree {
+				e.g += route.Cost
+				tree = append(tree, e)
+			}
+			numExplored += g.NumExplored
+			maxFrontier = max(maxFrontier, g.MaxFrontier)
+		}
+
+		if err != nil {
+			if n := len(g.Solution.Cells); n > 0 {
+				route.Legs = append(route.Legs, Leg{From: g.Start, To: g.Solution.Cells[n-1], Steps: n, Cost: g.Solution.Cost})
+				route.Actions = append(route.Actions, g.Solution.Actions...)
+				route.Cells = append(route.Cells, g.Solution.Cells...)
+				route.Cost += g.Solution.Cost
+			}
+			break
+		}
+
+		if len(g.Solution.Cells) == 0 && g.Start != stop {
+			solved = false
+			break
+		}
+
+		route.Legs = append(route.Legs, Leg{
+			From:  g.Start,
+			To:    stop,
+			Steps: len(g.Solution.Cells),
+			Cost:  g.Solution.Cost,
+		})
+		route.Actions = append(route.Actions, g.Solution.Actions...)
+		route.Cells = append(route.Cells, g.Solution.Cells...)
+		route.Cost += g.Solution.Cost
+		g.Start = stop
+	}
+
+	if combine {
+		g.explored = NewPointSet(g.Height, g.Width)
+		g.exploredIndex = nil
+		g.Explored = nil
+		for _, p := range explored {
+			g.markExplored(p)
+		}
+		g.PathCosts = pathCosts
+		g.tree = tree
+		g.NumExplored = numExplored
+		g.MaxFrontier = maxFrontier
+	}
+
+	if !solved {
+		g.setSolution(Solution{})
+		return nil
+	}
+	g.setSolution(route)
+	return err
+}
+
+// solveNearest runs a single search that ends at whichever goal it reaches
+// first.
+func (g *Maze) solveNearest(ctx context.Context, strategy Strategy) error {
+	g.targets = g.Goals
+	err := strategy.NewSolver(g).Solve(ctx)
+	g.targets = nil
+	if err != nil {
+		return err
+	}
+
+	reached := g.Start
+	if n := len(g.Solution.Cells); n > 0 {
+		reached = g.Solution.Cells[n-1]
+	} else if !slices.Contains(g.Goals, g.Start) {
+		return nil
+	}
+
+	g.Solution.Legs = []Leg{{
+		From:  g.Start,
+		To:    reached,
+		Steps: len(g.Solution.Cells),
+		Cost:  g.Solution.Cost,
+	}}
+	return nil
+}
+
+// tour works out the cheapest order to visit every goal in, finishing at B
+// when the maze has one. Costs between goals come from A*, and the order
+// from the Held-Karp dynamic programme, which is quick for the ten goals a
+// maze file can hold.
+func (g *Maze) tour(ctx context.Context) ([]Point, error) {
+	k := len(g.Goals)
+	cost, err := g.goalCosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// best[mask][j] is the cheapest way to visit the goals in mask, ending
+	// at goal j; row k of cost holds the costs from the start
+	full := 1<<k - 1
+	best := make([][]float64, full+1)
+	prev := make([][]int, full+1)
+	for mask := range best {
+		best[mask] = slices.Repeat([]float64{math.Inf(1)}, k)
+		prev[mask] = slices.Repeat([]int{-1}, k)
+	}
+	for j := range k {
+		best[1<<j][j] = cost[k][j]
+	}
+
+	for mask := 1; mask <= full; mask++ {
+		for j := range k {
+			if mask&(1<<j) == 0 || math.IsInf(best[mask][j], 1) {
+				continue
+			}
+			for next := range k {
+				if mask&(1<<next) != 0 {
+					continue
+				}
+				to := mask | 1<<next
+				if c := best[mask][j] + cost[j][next]; c < best[to][next] {
+					best[to][next] = c
+					prev[to][next] = j
+				}
+			}
+		}
+	}
+
+	// finish at B if there is one, otherwise wherever is cheapest
+	last := k - 1
+	if g.GoalLabels[g.Goals[last]] != "B" {
+		for j := range k {
+			if best[full][j] < best[full][last] {
+				last = j
+			}
+		}
+	}
+
+	order := make([]Point, 0, k)
+	for mask, j := full, last; j >= 0; {
+		order = append(order, g.Goals[j])
+		mask, j = mask&^(1<<j), prev[mask][j]
+		if j < 0 && bits.OnesCount(uint(mask)) > 0 {
+			// some goal can't be reached, so neither can the whole tour;
+			// visiting them in label order lets the legs report it
+			return g.Goals, nil
+		}
+	}
+	slices.Reverse(order)
+	return order, nil
+}
+
+// goalCosts finds the cost of the cheapest path between every pair of goals,
+// and from the start to each goal, using A* on a quiet copy of the maze so
+// nothing is animated or reported. Only the context limits how long it
+// takes.
+func (g *Maze) goalCosts(ctx context.Context) ([][]float64, error) {
+	probe := g.Fork()
+	probe.Progress = nil
+	probe.Limits = Limits{}
+	probe.targets = nil
+	probe.SetSeed(1)
+	astar := strategies["astar"]
+
+	k := len(g.Goals)
+	sources := append(slices.Clone(g.Goals), g.Start)
+	cost := make([][]float64, k+1)
+	for i, from := range sources {
+		cost[i] = make([]float64, k)
+		for j, to := range g.Goals {
+			if from == to {
+				continue
+			}
+			probe.Start, probe.Goal = from, to
+			if err := astar.NewSolver(probe).Solve(ctx); err != nil {
+				return nil, err
+			}
+			cost[i][j] = probe.Solution.Cost
+			if len(probe.Solution.Cells) == 0 {
+				cost[i][j] = math.Inf(1)
+			}
+		}
+	}
+	return cost, nil
+}
@@ -0,0 +1,89 @@
+package maze
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestSolveRoute solves goalsMaze with each route. A* and Dijkstra must find
+// the cheapest route; every other strategy must still find one that passes
+// Check, though a nearest route may stop at a goal further away.
+func TestSolveRoute(t *testing.T) {
+	tests := []struct {
+		route    string
+		order    []string
+		wantEnd  Point
+		wantLegs int
+		wantCost float64
+	}{
+		{route: "nearest", wantEnd: Point{Row: 1, Col: 3}, wantLegs: 1, wantCost: 2},
+		{route: "all", wantEnd: Point{Row: 3, Col: 3}, wantLegs: 3, wantCost: 8},
+		{route: "waypoints", wantEnd: Point{Row: 3, Col: 3}, wantLegs: 3, wantCost: 8},
+		// from 1 to B it is cheaper to go back round by 2 than through the mud
+		{route: "waypoints", order: []string{"2", "1", "B"}, wantEnd: Point{Row: 3, Col: 3}, wantLegs: 3, wantCost: 12},
+		{route: "waypoints", order: []string{"2"}, wantEnd: Point{Row: 1, Col: 5}, wantLegs: 1, wantCost: 4},
+	}
+
+	for _, tt := range tests {
+		name := tt.route
+		if tt.order != nil {
+			name += "/" + strings.Join(tt.order, ",")
+		}
+		t.Run(name, func(t *testing.T) {
+			for _, search := range StrategyNames() {
+				strategy, _ := LookupStrategy(search)
+				m := readMaze(t, goalsMaze)
+				m.Transpositions = true
+
+				result, err := SolveRoute(context.Background(), m, strategy, tt.route, tt.order)
+				if err != nil {
+					t.Fatalf("%s: %v", search, err)
+				}
+				if result.Status != Solved {
+					t.Fatalf("%s: status %v, want solved", search, result.Status)
+				}
+				if err := m.Check(result.Solution); err != nil {
+					t.Errorf("%s: %v", search, err)
+				}
+				if got := len(result.Solution.Legs); got != tt.wantLegs {
+					t.Errorf("%s: %d legs, want %d", search, got, tt.wantLegs)
+				}
+				if search != "astar" && search != "dijkstra" {
+					continue
+				}
+				if result.Closest != tt.wantEnd {
+					t.Errorf("%s: route ends at %v, want %v", search, result.Closest, tt.wantEnd)
+				}
+				if result.Solution.Cost != tt.wantCost {
+					t.Errorf("%s: route costs %v, want %v", search, result.Solution.Cost, tt.wantCost)
+				}
+			}
+		})
+	}
+}
+
+// TestSolveRouteErrors checks that unknown routes and goal labels are
+// reported rather than searched for.
+func TestSolveRouteErrors(t *testing.T) {
+	strategy, _ := LookupStrategy("astar")
+
+	tests := []struct {
+		route   string
+		order   []string
+		wantErr string
+	}{
+		{route: "scenic", wantErr: `unknown route "scenic"`},
+		{route: "waypoints", order: []string{"1", "3"}, wantErr: `no goal labelled "3"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			m := readMaze(t, goalsMaze)
+			_, err := SolveRoute(context.Background(), m, strategy, tt.route, tt.order)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error %v, want one mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package maze
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Search is the generic search engine shared by every algorithm. It expands
+// nodes in whatever order its Frontier hands them back, so the algorithm is
+// determined entirely by the frontier it is given.
+type Search struct {
+	Name     string
+	Frontier Frontier
+	Game     *Maze
+}
+
+func (s *Search) Solve(ctx context.Context) error {
+	s.Game.reportf(Started, "%s", s.Name)
+
+	s.Game.resetSearch()
+	defer func() {
+		s.Game.recordFrontier(s.Frontier.GetFrontier())
+	}()
+
+	start := Node{
+		State:  s.Game.Start,
+		Parent: nil,
+		Action: "",
+	}
+
+	s.Frontier.Add(&start)
+	s.Game.trackFrontier(1)
+	s.Game.CurrentNode = &start
+
+	for {
+		if s.Frontier.Empty() {
+			return nil
+		}
+
+		if s.Game.Debug {
+			var states []Point
+			for _, x := range s.Frontier.GetFrontier() {
+				states = append(states, x.State)
+			}
+			s.Game.reportf(Detail, "frontier before remove: %v", states)
+		}
+
+		currentNode, err := s.Frontier.Remove()
+		if err != nil {
+			return err
+		}
+
+		s.Game.reportf(Detail, "removed %v", currentNode.State)
+
+		if err := s.Game.visit(ctx, currentNode); err != nil {
+			return s.Game.giveUp(err)
+		}
+
+		// have we found the solution?
+		if s.Game.isGoal(currentNode.State) {
+			s.Game.setSolution(solutionFrom(currentNode))
+			return nil
+		}
+
+		s.Game.frame()
+
+		for _, x := range s.Game.Neighbors(currentNode) {
+			cost := x.PathCost
+
+			if s.Frontier.ContainsState(x) {
+				s.reprioritize(x, currentNode, cost)
+				continue
+			}
+
+			if !s.Game.inExplored(x.State) {
+				s.Frontier.Add(&Node{
+					State:    x.State,
+					Parent:   currentNode,
+					Action:   x.Action,
+					PathCost: cost,
+				})
+			}
+		}
+
+		s.Game.trackFrontier(len(s.Frontier.GetFrontier()))
+	}
+}
+
+// reprioritize re-parents a node that is already on the frontier when a
+// cheaper path to it has been found, if the frontier supports decrease-key.
+func (s *Search) reprioritize(x, parent *Node, cost float64) {
+	r, ok := s.Frontier.(Reprioritizer)
+	if !ok {
+		return
+	}
+
+	existing := r.Lookup(x.State)
+	if existing == nil || cost >= existing.PathCost {
+		return
+	}
+
+	existing.Parent = parent
+	existing.Action = x.Action
+	existing.PathCost = cost
+	r.Fix(existing)
+}
+
+// solutionFrom walks back from the goal node to the start, collecting the
+// actions taken and cells visited along the way.
+func solutionFrom(node *Node) Solution {
+	cost := node.PathCost
+	var actions []string
+	var cells []Point
+
+	for {
+		if node.Parent != nil {
+			actions = append(actions, node.Action)
+			cells = append(cells, node.State)
+			node = node.Parent
+		} else {
+			break
+		}
+	}
+
+	slices.Reverse(actions)
+	slices.Reverse(cells)
+
+	return Solution{
+		Actions: actions,
+		Cells:   cells,
+		Cost:    cost,
+	}
+}
+
+// visit records n as the node currently being expanded. Every solver calls
+// it once per expansion so images, statistics and limits work the same for
+// all of them. It returns an error, and the solver must stop, if a limit has
+// been reached.
+func (g *Maze) visit(ctx context.Context, n *Node) error {
+	if err := g.limit(ctx); err != nil {
+		return err
+	}
+	g.expand(n)
+	return nil
+}
+
+// limit returns an error if the context is done or the maze's node budget
+// has been spent.
+func (g *Maze) limit(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// a busy search can keep the timer that ends the context from running
+	// for a while, so check the deadline itself every so often
+	if g.NumExplored%64 == 0 {
+		if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+			return context.DeadlineExceeded
+		}
+	}
+
+	if g.Limits.MaxExpanded > 0 && g.NumExplored >= g.Limits.MaxExpanded {
+		return ErrBudget
+	}
+	return nil
+}
+
+// expand records n as expanded, for solvers that check the limits
+// themselves before doing the work of an expansion.
+func (g *Maze) expand(n *Node) {
+	if g.Animation != nil {
+		if g.CurrentNode != nil {
+			g.Animation.MarkDirty(g.CurrentNode.State)
+		}
+		g.Animation.MarkDirty(n.State)
+	}
+
+	g.CurrentNode = n
+	g.NumExplored += 1
+	g.markExplored(n.State)
+	g.PathCosts[n.State] = n.PathCost
+	g.consider(n)
+	g.keep(n)
+	g.report(Progress{Kind: Expanded, At: n.State, Explored: g.NumExplored})
+}
+
+// trackFrontier records the largest number of nodes a solver has held in
+// memory waiting to be expanded.
+func (g *Maze) trackFrontier(size int) {
+	g.MaxFrontier = max(g.MaxFrontier, size)
+}
+
+// frame records an animation frame of the search so far, if requested.
+func (g *Maze) frame() {
+	if g.Animation != nil {
+		g.Animation.Capture(g)
+	}
+}
+
+// Neighbors returns the cells reachable from node in one move, in random
+// order, with their path cost filled in.
+func (g *Maze) Neighbors(node *Node) []*Node {
+	moves := g.movement().Moves(node.State)
+
+	neighbors := make([]*Node, 0, len(moves))
+	for _, move := range moves {
+		if !g.allowed(node.State, move) {
+			continue
+		}
+
+		p := Point{Row: node.State.Row + move.Row, Col: node.State.Col + move.Col}
+		neighbors = append(neighbors, &Node{
+			State:    p,
+			Parent:   node,
+			Action:   move.Action,
+			PathCost: node.PathCost + g.StepCost(p)*move.Length,
+		})
+	}
+
+	// randomness
+	if g.Rand == nil {
+		g.SetSeed(time.Now().UnixNano())
+	}
+	for i := range neighbors {
+		j := g.Rand.Intn(i + 1)
+		neighbors[i], neighbors[j] = neighbors[j], neighbors[i]
+	}
+
+	return neighbors
+}
@@ -0,0 +1,177 @@
+package maze
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SolutionFile is a solution as it is saved by the solve and serve commands,
+// for verify to read back. It may give the actions taken, the cells
+// visited, or both; cells are [row, col] pairs and don't include the start.
+//
+// For a maze with several goals it records the route taken and, for the
+// waypoints route, the labels of the goals visited in order, so the solution
+// can be checked against them.
+//
+// Solutions can also be written by hand as text, one step to a line: either
+// an action name, such as "up", or a cell as "row,col". Blank lines and lines
+// starting with # are ignored.
+type SolutionFile struct {
+	Maze      string   `json:"maze,omitempty"`
+	Search    string   `json:"search,omitempty"`
+	Movement  string   `json:"movement,omitempty"`
+	Route     string   `json:"route,omitempty"`
+	Waypoints []string `json:"waypoints,omitempty"`
+	Start     *[2]int  `json:"start,omitempty"`
+	Actions   []string `json:"actions,omitempty"`
+	Cells     [][2]int `json:"cells,omitempty"`
+	Cost      *float64 `json:"cost,omitempty"`
+}
+
+// SolutionFile returns s, found by the named search, in the form it is saved
+// in.
+func (g *Maze) SolutionFile(s Solution, search string) SolutionFile {
+	cost := s.Cost
+	sf := SolutionFile{
+		Maze:     g.Name,
+		Search:   search,
+		Movement: g.movement().Name,
+		Start:    &[2]int{g.Start.Row, g.Start.Col},
+		Actions:  append([]string{}, s.Actions...),
+		Cells:    make([][2]int, 0, len(s.Cells)),
+		Cost:     &cost,
+	}
+	for _, p := range s.Cells {
+		sf.Cells = append(sf.Cells, [2]int{p.Row, p.Col})
+	}
+	if len(g.Goals) > 1 {
+		sf.Route = s.Route
+		for _, p := range s.Waypoints {
+			sf.Waypoints = append(sf.Waypoints, g.GoalLabels[p])
+		}
+	}
+	return sf
+}
+
+// ReadSolution parses a solution file, in JSON if it starts with a brace and
+// in the text form otherwise.
+func ReadSolution(r io.Reader) (SolutionFile, error) {
+	var sf SolutionFile
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return sf, err
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		// the serve command's answers carry more than the solution, so
+		// unknown fields are allowed
+		if err := json.Unmarshal(data, &sf); err != nil {
+			return sf, err
+		}
+		return sf, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		row, col, isCell := strings.Cut(line, ",")
+		if !isCell {
+			sf.Actions = append(sf.Actions, line)
+			continue
+		}
+		r, err1 := strconv.Atoi(strings.TrimSpace(row))
+		c, err2 := strconv.Atoi(strings.TrimSpace(col))
+		if err1 != nil || err2 != nil {
+			return sf, fmt.Errorf("line %d: want an action or row,col, got %q", n, line)
+		}
+		sf.Cells = append(sf.Cells, [2]int{r, c})
+	}
+	if err := scanner.Err(); err != nil {
+		return sf, err
+	}
+
+	if sf.Actions != nil && sf.Cells != nil {
+		return sf, fmt.Errorf("solution mixes actions and cells; give one or the other")
+	}
+	return sf, nil
+}
+
+// Solution turns a solution file into a Solution of the maze, ready to Check.
+// Given only actions, it follows them from the start to find the cells; it
+// doesn't check the moves are legal, which is Check's job. Without a cost,
+// the solution is given what its steps add up to.
+func (sf SolutionFile) Solution(g *Maze) (Solution, error) {
+	var s Solution
+
+	if sf.Start != nil && (sf.Start[0] != g.Start.Row || sf.Start[1] != g.Start.Col) {
+		return s, fmt.Errorf("solution starts at %v, but the maze starts at %v", Point{Row: sf.Start[0], Col: sf.Start[1]}, g.Start)
+	}
+
+	if len(sf.Actions) > 0 {
+		s.Actions = sf.Actions
+	}
+	s.Route = sf.Route
+	if len(sf.Waypoints) > 0 {
+		var err error
+		if s.Waypoints, err = g.stops(sf.Waypoints); err != nil {
+			return s, err
+		}
+	}
+	for _, c := range sf.Cells {
+		s.Cells = append(s.Cells, Point{Row: c[0], Col: c[1]})
+	}
+
+	if s.Cells == nil && s.Actions != nil {
+		at := g.Start
+		for i, action := range s.Actions {
+			move, ok := moveNamed(g.movement().Moves(at), action)
+			if !ok {
+				return s, fmt.Errorf("step %d: %s has no move called %q", i+1, g.movement().Name, action)
+			}
+			at = Point{Row: at.Row + move.Row, Col: at.Col + move.Col}
+			s.Cells = append(s.Cells, at)
+		}
+	}
+
+	if sf.Cost != nil {
+		s.Cost = *sf.Cost
+	} else {
+		s.Cost = g.pathCost(s.Cells)
+	}
+	return s, nil
+}
+
+// moveNamed finds the move for an action among moves.
+func moveNamed(moves []Move, action string) (Move, bool) {
+	for _, move := range moves {
+		if move.Action == action {
+			return move, true
+		}
+	}
+	return Move{}, false
+}
+
+// pathCost adds up the cost of stepping along cells from the start, as far
+// as the steps are moves between neighbours.
+func (g *Maze) pathCost(cells []Point) float64 {
+	from, cost := g.Start, 0.0
+	for _, to := range cells {
+		move, ok := g.moveBetween(from, to)
+		if !ok {
+			break
+		}
+		cost += g.StepCost(to) * move.Length
+		from = to
+	}
+	return cost
+}
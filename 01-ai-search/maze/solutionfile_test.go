@@ -0,0 +1,87 @@
+package maze
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestReadSolution reads hand-written and saved solutions, and checks the
+// steps they give against goalsMaze.
+func TestReadSolution(t *testing.T) {
+	m := readMaze(t, goalsMaze)
+
+	tests := []struct {
+		name      string
+		text      string
+		wantCells []Point
+		wantCost  float64
+		wantErr   string
+	}{
+		{
+			name:      "actions",
+			text:      "# to goal 1\nright\n\nright\n",
+			wantCells: cells([2]int{1, 2}, [2]int{1, 3}),
+			wantCost:  2,
+		},
+		{
+			name:      "cells",
+			text:      "2,1\n 3, 1\n# the mud is dear\n3,2\n",
+			wantCells: cells([2]int{2, 1}, [2]int{3, 1}, [2]int{3, 2}),
+			wantCost:  5,
+		},
+		{
+			name:      "json",
+			text:      `{"actions": ["down", "down"], "cost": 4, "elapsed": "1ms"}`,
+			wantCells: cells([2]int{2, 1}, [2]int{3, 1}),
+			wantCost:  4,
+		},
+		{
+			name:    "actions then cells",
+			text:    "right\n1,3\n",
+			wantErr: "mixes actions and cells",
+		},
+		{
+			name:    "cells then actions",
+			text:    "1,2\nright\n",
+			wantErr: "mixes actions and cells",
+		},
+		{
+			name:    "bad cell",
+			text:    "1,2\n\n1,x\n",
+			wantErr: `line 3: want an action or row,col, got "1,x"`,
+		},
+		{
+			name:    "unknown action",
+			text:    "right\nnorth\n",
+			wantErr: `step 2: grid4 has no move called "north"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sf, err := ReadSolution(strings.NewReader(tt.text))
+			var s Solution
+			if err == nil {
+				s, err = sf.Solution(m)
+			}
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Fatalf("read %+v, want an error mentioning %q", sf, tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Fatalf("error %q does not mention %q", err, tt.wantErr)
+			case tt.wantErr != "":
+				return
+			}
+
+			if !reflect.DeepEqual(s.Cells, tt.wantCells) {
+				t.Errorf("cells = %v, want %v", s.Cells, tt.wantCells)
+			}
+			if s.Cost != tt.wantCost {
+				t.Errorf("cost = %v, want %v", s.Cost, tt.wantCost)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package maze
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ProgressKind says what a Progress report is about.
+type ProgressKind int
+
+const (
+	Started  ProgressKind = iota // a solver has started; Message names it
+	Expanded                     // a cell has been expanded
+	Notice                       // something the caller may want to tell the user
+	Detail                       // fine detail, only reported when the maze's Debug is set
+)
+
+// Progress is a report from a solver as it works, passed to the maze's
+// Progress callback.
+type Progress struct {
+	Kind     ProgressKind
+	At       Point // the cell expanded, for Expanded
+	Explored int   // cells expanded so far
+	Message  string
+}
+
+// report passes p to the maze's Progress callback, if it has one.
+func (g *Maze) report(p Progress) {
+	if g.Progress != nil {
+		g.Progress(p)
+	}
+}
+
+// reportf reports a formatted message of the given kind. Detail messages
+// are only formatted when Debug is set.
+func (g *Maze) reportf(kind ProgressKind, format string, args ...any) {
+	if g.Progress == nil || kind == Detail && !g.Debug {
+		return
+	}
+	g.Progress(Progress{Kind: kind, Explored: g.NumExplored, Message: fmt.Sprintf(format, args...)})
+}
+
+// Limits bound how much work a search may do. A zero field means no limit.
+type Limits struct {
+	MaxExpanded int           // the most cells one search may expand; a route runs a search per leg
+	Timeout     time.Duration // how long Solve may run for
+}
+
+// ErrBudget is returned by a solver that has expanded as many cells as the
+// maze's Limits allow.
+var ErrBudget = errors.New("node budget exceeded")
+
+// Status says how a solve ended.
+type Status int
+
+const (
+	Solved         Status = iota // the goal was reached
+	NoSolution                   // the search finished without reaching the goal
+	Cancelled                    // the context was cancelled
+	BudgetExceeded               // the node budget ran out
+	TimedOut                     // the time limit or the context's deadline passed
+)
+
+var statusNames = map[Status]string{
+	Solved:         "solved",
+	NoSolution:     "no solution",
+	Cancelled:      "cancelled",
+	BudgetExceeded: "node budget exceeded",
+	TimedOut:       "time limit reached",
+}
+
+func (s Status) String() string {
+	return statusNames[s]
+}
+
+// Stopped reports whether the search was stopped before it could finish.
+func (s Status) Stopped() bool {
+	return s != Solved && s != NoSolution
+}
+
+// statusOf turns the error a solve ended with into its status. Errors that
+// aren't from a limit are returned as they are.
+func statusOf(err error) (Status, error) {
+	switch {
+	case err == nil:
+		return Solved, nil
+	case errors.Is(err, ErrBudget):
+		return BudgetExceeded, nil
+	case errors.Is(err, context.DeadlineExceeded):
+		return TimedOut, nil
+	case errors.Is(err, context.Canceled):
+		return Cancelled, nil
+	}
+	return 0, err
+}
+
+// consider keeps track of the node nearest the goal by the heuristic, whose
+// path is the best a search has to offer if it is stopped early.
+func (g *Maze) consider(n *Node) {
+	if h := g.Heuristic(n.State); g.closest == nil || h < g.closestH {
+		g.closest, g.closestH = n, h
+	}
+}
+
+// giveUp ends a search stopped by err, leaving the path to the closest cell
+// it reached as the solution.
+func (g *Maze) giveUp(err error) error {
+	var partial Solution
+	if g.closest != nil {
+		partial = solutionFrom(g.closest)
+	}
+	g.setSolution(partial)
+	return err
+}
+
+// Result is what solving a maze found. When Status says the search was
+// stopped early, Solution is the path to Closest, the cell it reached that
+// looked nearest the goal.
+type Result struct {
+	Search      string
+	Status      Status
+	Solution    Solution
+	Closest     Point
+	Explored    []Point
+	NumExplored int
+	MaxFrontier int
+	Elapsed     time.Duration
+}
+
+// Solve solves the maze with strategy, visiting every goal in the order the
+// maze gives them, and returns what it found. The maze is left holding the
+// search's state, so it can be drawn afterwards.
+//
+// The search stops early if ctx is done or the maze's Limits are reached.
+// That isn't an error: the Result's Status says what happened, and its
+// Solution gets as close to the goal as the search managed.
+func Solve(ctx context.Context, m *Maze, strategy Strategy) (Result, error) {
+	return SolveRoute(ctx, m, strategy, "waypoints", nil)
+}
+
+// SolveRoute is Solve for mazes with several goals, visiting them as the
+// named route describes; see Maze.Route.
+func SolveRoute(ctx context.Context, m *Maze, strategy Strategy, route string, order []string) (Result, error) {
+	if m.Limits.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.Limits.Timeout)
+		defer cancel()
+	}
+
+	m.SearchType = strategy.SearchType
+	startTime := time.Now()
+	status, err := statusOf(m.Route(ctx, strategy, route, order))
+	if err != nil {
+		return Result{}, err
+	}
+
+	if status == Solved && len(m.Solution.Cells) == 0 && !m.isGoal(m.Start) {
+		status = NoSolution
+	}
+
+	closest := m.Start
+	if n := len(m.Solution.Cells); n > 0 {
+		closest = m.Solution.Cells[n-1]
+	}
+
+	return Result{
+		Search:      strategy.Name,
+		Status:      status,
+		Solution:    m.Solution,
+		Closest:     closest,
+		Explored:    m.Explored,
+		NumExplored: m.NumExplored,
+		MaxFrontier: m.MaxFrontier,
+		Elapsed:     time.Since(startTime),
+	}, nil
+}
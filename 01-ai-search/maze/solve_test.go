@@ -0,0 +1,104 @@
+package maze
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// benchSizes are the widths and heights of the mazes the benchmarks solve.
+// Time per explored node should stay roughly flat as the maze grows, since
+// explored and frontier membership are constant time.
+var benchSizes = []int{31, 101, 251}
+
+// BenchmarkSolveOpenRoom solves an open room with a pillar on every other
+// cell using every registered strategy. It has many equally short paths,
+// which makes the uninformed searches explore most of it.
+func BenchmarkSolveOpenRoom(b *testing.B) {
+	for _, size := range benchSizes {
+		benchmarkStrategies(b, size, openRoom(b, size))
+	}
+}
+
+// BenchmarkSolveGenerated solves braided mazes from each generator algorithm
+// using every registered strategy.
+func BenchmarkSolveGenerated(b *testing.B) {
+	for _, algorithm := range GeneratorNames() {
+		b.Run(algorithm, func(b *testing.B) {
+			for _, size := range benchSizes {
+				gen := Generator{Width: size, Height: size, Algorithm: algorithm, Braid: 0.5, Seed: 1}
+
+				var text strings.Builder
+				if err := gen.Write(&text); err != nil {
+					b.Fatal(err)
+				}
+				benchmarkStrategies(b, size, readMaze(b, text.String()))
+			}
+		})
+	}
+}
+
+// maxDeepeningSize is the largest maze each iterative deepening search is
+// benchmarked on. They walk the maze again every time the bound grows, so
+// larger mazes take minutes.
+var maxDeepeningSize = map[int]int{IDDFS: 31, IDASTAR: 101}
+
+// benchmarkStrategies runs a sub-benchmark per strategy on m, reporting the
+// cells explored and the time taken per explored cell. IDDFS and IDA* keep
+// their transposition table, since without it they take exponential time on
+// mazes this full of loops.
+func benchmarkStrategies(b *testing.B, size int, m *Maze) {
+	m.Transpositions = true
+	for _, name := range StrategyNames() {
+		strategy, _ := LookupStrategy(name)
+		if limit, ok := maxDeepeningSize[strategy.SearchType]; ok && size > limit {
+			continue
+		}
+		b.Run(fmt.Sprintf("%s/%dx%d", name, size, size), func(b *testing.B) {
+			m.SetSeed(1)
+			b.ReportAllocs()
+			for b.Loop() {
+				_ = strategy.NewSolver(m).Solve(context.Background())
+			}
+			if m.NumExplored > 0 {
+				b.ReportMetric(float64(m.NumExplored), "explored")
+				b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/float64(m.NumExplored), "ns/node")
+			}
+		})
+	}
+}
+
+// openRoom builds a size x size room surrounded by walls, with a pillar on
+// every other cell, and A and B in opposite corners.
+func openRoom(b *testing.B, size int) *Maze {
+	size |= 1 // odd sizes keep the corners clear of pillars
+
+	var text strings.Builder
+	for row := range size {
+		for col := range size {
+			switch {
+			case row == 1 && col == 1:
+				text.WriteByte('A')
+			case row == size-2 && col == size-2:
+				text.WriteByte('B')
+			case row == 0 || col == 0 || row == size-1 || col == size-1:
+				text.WriteByte('#')
+			case row%2 == 0 && col%2 == 0:
+				text.WriteByte('#')
+			default:
+				text.WriteByte(' ')
+			}
+		}
+		text.WriteByte('\n')
+	}
+	return readMaze(b, text.String())
+}
+
+func readMaze(tb testing.TB, text string) *Maze {
+	var m Maze
+	if err := m.Read(strings.NewReader(text)); err != nil {
+		tb.Fatal(err)
+	}
+	return &m
+}
@@ -0,0 +1,70 @@
+package maze
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+)
+
+// svgRenderer draws the maze as a scalable vector image, with the same
+// colours and text as the png renderer.
+type svgRenderer struct{}
+
+func (svgRenderer) Render(w io.Writer, g *Maze) error {
+	bw := bufio.NewWriter(w)
+	size := g.imageSize()
+	cell := float64(g.cellSize())
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", size.X, size.Y, size.X, size.Y)
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" fill="black"/>`+"\n", size.X, size.Y)
+
+	fmt.Fprintf(bw, `<g stroke="%s" stroke-width="1">`+"\n", svgColour(gray))
+	for i, row := range g.Walls {
+		for j := range row {
+			p := Point{Row: i, Col: j}
+			b := g.cellBounds(p)
+			fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", b.Min.X, b.Min.Y, b.Dx(), b.Dy(), svgColour(g.cellColour(p)))
+		}
+	}
+	fmt.Fprintln(bw, "</g>")
+
+	fmt.Fprintf(bw, `<g font-family="monospace" font-size="%.1f">`+"\n", cell*13/60)
+	for i, row := range g.Walls {
+		for j := range row {
+			p := Point{Row: i, Col: j}
+			b := g.cellBounds(p)
+			for _, label := range g.cellLabels(p) {
+				anchor := ""
+				if label.Right {
+					anchor = ` text-anchor="end"`
+				}
+				fmt.Fprintf(bw, `<text x="%.1f" y="%.1f" fill="%s"%s>`, float64(b.Min.X)+label.X*cell, float64(b.Min.Y)+label.Y*cell, svgColour(label.Colour), anchor)
+				_ = xml.EscapeText(bw, []byte(label.Text))
+				fmt.Fprintln(bw, "</text>")
+			}
+		}
+	}
+	fmt.Fprintln(bw, "</g>")
+
+	if g.showPath() {
+		for i, leg := range g.pathLegs() {
+			var points []string
+			for _, p := range leg {
+				b := g.cellBounds(p)
+				points = append(points, fmt.Sprintf("%.1f,%.1f", float64(b.Min.X+b.Max.X)/2, float64(b.Min.Y+b.Max.Y)/2))
+			}
+			fmt.Fprintf(bw, `<polyline points="%s" fill="none" stroke="%s" stroke-width="3"/>`+"\n",
+				strings.Join(points, " "), svgColour(legColours[i%len(legColours)]))
+		}
+	}
+
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+func svgColour(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
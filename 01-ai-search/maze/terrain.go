@@ -0,0 +1,28 @@
+package maze
+
+// FloodedCost is the cost of stepping into a flooded cell.
+const FloodedCost = 1000
+
+// Terrain describes a kind of open cell and how much it costs to step into it.
+type Terrain struct {
+	Symbol rune
+	Name   string
+	Cost   float64
+}
+
+// terrains maps the characters used in maze files to the terrain they
+// represent. New kinds of terrain only need an entry here.
+var terrains = map[rune]Terrain{
+	' ': {Symbol: ' ', Name: "open", Cost: 1},
+	'w': {Symbol: 'w', Name: "water", Cost: FloodedCost},
+}
+
+// StepCost returns the cost of moving into p.
+func (g *Maze) StepCost(p Point) float64 {
+	return g.Walls[p.Row][p.Col].Terrain.Cost
+}
+
+// Water reports whether the cell is flooded.
+func (w Wall) Water() bool {
+	return w.Terrain.Symbol == 'w'
+}
@@ -0,0 +1,192 @@
+package maze
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// expansion is a node as it was expanded, kept when the maze's KeepTree is
+// set. The path cost and heuristic are recorded then, because a route
+// through several goals changes the start and goal between legs.
+type expansion struct {
+	node *Node
+	g, h float64
+}
+
+// keep records n in the search tree, if the maze is keeping one.
+func (g *Maze) keep(n *Node) {
+	if g.KeepTree {
+		g.tree = append(g.tree, expansion{node: n, g: n.PathCost, h: g.Heuristic(n.State)})
+	}
+}
+
+// TreeNode is one expansion in a search tree.
+type TreeNode struct {
+	ID     int     `json:"id"`     // the order the node was expanded in, from 0
+	Parent int     `json:"parent"` // the ID of the node it was reached from, or -1 for a root
+	Row    int     `json:"row"`
+	Col    int     `json:"col"`
+	Action string  `json:"action,omitempty"` // the move from the parent
+	G      float64 `json:"g"`                // path cost from the start
+	H      float64 `json:"h"`                // heuristic estimate of the cost to the goal
+	F      float64 `json:"f"`                // g + h
+	OnPath bool    `json:"on_path,omitempty"`
+}
+
+// SearchTree is every node a search expanded, linked to its parent. Most
+// searches grow a single tree from the start; bidirectional search grows a
+// second from the goal, iterative deepening one per iteration, and a route
+// through several goals one per leg. D* Lite keeps no parent links, so its
+// nodes are all roots.
+type SearchTree struct {
+	Maze   string     `json:"maze,omitempty"`
+	Search string     `json:"search"`
+	Nodes  []TreeNode `json:"nodes"`
+}
+
+// SearchTree returns the tree the last search, by the named strategy, grew.
+// It is empty unless KeepTree was set before the search.
+func (g *Maze) SearchTree(search string) SearchTree {
+	t := SearchTree{Maze: g.Name, Search: search, Nodes: make([]TreeNode, 0, len(g.tree))}
+
+	// iterative deepening expands the same nodes again in every iteration,
+	// so a node's parent is the parent's latest expansion before it
+	ids := make(map[*Node]int, len(g.tree))
+	for i, e := range g.tree {
+		parent := -1
+		if e.node.Parent != nil {
+			if id, ok := ids[e.node.Parent]; ok {
+				parent = id
+			}
+		}
+		ids[e.node] = i
+		t.Nodes = append(t.Nodes, TreeNode{
+			ID:     i,
+			Parent: parent,
+			Row:    e.node.State.Row,
+			Col:    e.node.State.Col,
+			Action: e.node.Action,
+			G:      e.g,
+			H:      e.h,
+			F:      e.g + e.h,
+			OnPath: e.node.State == g.Start || g.inSolution(e.node.State),
+		})
+	}
+	return t
+}
+
+// WriteDOT writes the tree in Graphviz's DOT language, with nodes on the
+// solution coloured as they are in images.
+func (t SearchTree) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "digraph %q {\n", t.Search+" search tree")
+	fmt.Fprintln(bw, "  node [shape=box, style=filled, fontname=\"Helvetica\", fontsize=10];")
+	fmt.Fprintln(bw, "  edge [fontname=\"Helvetica\", fontsize=9];")
+	for _, n := range t.Nodes {
+		fill := yellow
+		if n.OnPath {
+			fill = green
+		}
+		fmt.Fprintf(bw, "  n%d [label=\"#%d (%d,%d)\\ng=%s h=%s f=%s\", fillcolor=%q];\n",
+			n.ID, n.ID, n.Row, n.Col, FormatCost(n.G), FormatCost(n.H), FormatCost(n.F), svgColour(fill))
+	}
+	for _, n := range t.Nodes {
+		if n.Parent >= 0 {
+			fmt.Fprintf(bw, "  n%d -> n%d [label=%q];\n", n.Parent, n.ID, n.Action)
+		}
+	}
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
+
+// GraphNode is an open cell of the maze.
+type GraphNode struct {
+	ID      string  `json:"id"` // "row,col"
+	Row     int     `json:"row"`
+	Col     int     `json:"col"`
+	Terrain string  `json:"terrain"`
+	Cost    float64 `json:"cost"` // the cost of stepping into the cell
+}
+
+// GraphEdge is a move between two open cells. The cost of a move depends on
+// the cell moved into, so each direction is its own edge.
+type GraphEdge struct {
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Action string  `json:"action"`
+	Cost   float64 `json:"cost"`
+}
+
+// Graph is the state space searches explore: a node for every open cell
+// and an edge for every move the movement model allows between them.
+type Graph struct {
+	Maze     string      `json:"maze,omitempty"`
+	Movement string      `json:"movement"`
+	Nodes    []GraphNode `json:"nodes"`
+	Edges    []GraphEdge `json:"edges"`
+
+	hex     bool              // odd rows are drawn offset by half a cell
+	colours map[string]string // fill colours for DOT, as images draw the cells
+}
+
+// Graph returns the maze as a graph.
+func (g *Maze) Graph() Graph {
+	gr := Graph{Maze: g.Name, Movement: g.movement().Name, hex: g.movement().Hex, colours: make(map[string]string)}
+
+	for row := range g.Walls {
+		for col := range g.Walls[row] {
+			p := Point{Row: row, Col: col}
+			if !g.open(p) {
+				continue
+			}
+
+			id := graphID(p)
+			gr.Nodes = append(gr.Nodes, GraphNode{ID: id, Row: row, Col: col, Terrain: g.Walls[row][col].Terrain.Name, Cost: g.StepCost(p)})
+			gr.colours[id] = svgColour(g.cellColour(p))
+
+			for _, move := range g.movement().Moves(p) {
+				if !g.allowed(p, move) {
+					continue
+				}
+				to := Point{Row: row + move.Row, Col: col + move.Col}
+				gr.Edges = append(gr.Edges, GraphEdge{From: id, To: graphID(to), Action: move.Action, Cost: g.StepCost(to) * move.Length})
+			}
+		}
+	}
+	return gr
+}
+
+func graphID(p Point) string {
+	return fmt.Sprintf("%d,%d", p.Row, p.Col)
+}
+
+// WriteDOT writes the graph in Graphviz's DOT language. Nodes are pinned to
+// their cells, so neato -n lays the graph out as the maze, and coloured as
+// images draw them, showing any search the maze has been through.
+func (gr Graph) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "digraph %q {\n", gr.Movement+" maze graph")
+	fmt.Fprintln(bw, "  node [shape=circle, style=filled, fontname=\"Helvetica\", fontsize=8, width=0.4, fixedsize=true];")
+	fmt.Fprintln(bw, "  edge [fontname=\"Helvetica\", fontsize=7, arrowsize=0.4];")
+	for _, n := range gr.Nodes {
+		fill := gr.colours[n.ID]
+		if fill == "" {
+			fill = svgColour(white)
+		}
+		x := n.Col * 72
+		if gr.hex && n.Row%2 == 1 {
+			x += 36
+		}
+		fmt.Fprintf(bw, "  %q [pos=\"%d,%d!\", fillcolor=%q];\n", n.ID, x, -n.Row*72, fill)
+	}
+	for _, e := range gr.Edges {
+		fmt.Fprintf(bw, "  %q -> %q [label=%q];\n", e.From, e.To, FormatCost(e.Cost))
+	}
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
@@ -0,0 +1,43 @@
+package maze
+
+import (
+	"context"
+	"testing"
+)
+
+// loopyMaze has more than one way round, so searches reach some cells from
+// several directions.
+const loopyMaze = `##B   #
+## ## #
+#  #  #
+# ## ##
+     ##
+A######
+`
+
+// TestSearchTreeParents checks that every strategy's tree links each node
+// to a parent expanded before it, which iterative deepening, expanding the
+// start again in every iteration, once broke.
+func TestSearchTreeParents(t *testing.T) {
+	for _, name := range StrategyNames() {
+		t.Run(name, func(t *testing.T) {
+			m := readMaze(t, loopyMaze)
+			m.KeepTree = true
+			m.SetSeed(1)
+			strategy, _ := LookupStrategy(name)
+			if err := strategy.NewSolver(m).Solve(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			tree := m.SearchTree(name)
+			if len(tree.Nodes) == 0 {
+				t.Fatal("empty search tree")
+			}
+			for _, n := range tree.Nodes {
+				if n.Parent >= n.ID {
+					t.Errorf("node %d at (%d,%d) has parent %d", n.ID, n.Row, n.Col, n.Parent)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"ai-search/maze"
+)
+
+// runRace implements the race command, which solves a maze with several
+// search strategies at once and reports whichever finishes first.
+func runRace(args []string) {
+	var m maze.Maze
+	var mazeFile, searches, outfile, movement string
+	var seed int64
+
+	fs := flag.NewFlagSet("race", flag.ExitOnError)
+	fs.StringVar(&mazeFile, "file", "maze.txt", "maze file")
+	fs.StringVar(&searches, "search", "astar,bibfs,jps", "comma separated search types to race ("+strings.Join(maze.StrategyNames(), ", ")+")")
+	fs.StringVar(&outfile, "image", "image.png", "image of the winner's search to write (- for the terminal, empty for none)")
+	fs.StringVar(&movement, "movement", "", "movement model ("+strings.Join(maze.MovementNames(), ", ")+"), overriding the maze file's")
+	fs.BoolVar(&m.Transpositions, "transpositions", true, "let iddfs and idastar remember the cheapest way to every cell; without it they can take minutes on mazes with loops")
+	fs.Int64Var(&seed, "seed", 0, "random seed for the order neighbours are explored in (0 picks one from the clock)")
+	overlay := addViewFlags(fs, &m.View)
+	addLimitFlags(fs, &m.Limits)
+	_ = fs.Parse(args)
+
+	if err := m.View.SetOverlays(*overlay); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var racers []maze.Strategy
+	for name := range strings.SplitSeq(searches, ",") {
+		strategy, err := maze.LookupStrategy(strings.TrimSpace(name))
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		racers = append(racers, strategy)
+	}
+
+	if movement != "" {
+		var err error
+		m.Movement, err = maze.LookupMovement(movement)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	if err := m.Load(mazeFile); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	m.SetSeed(seed)
+	fmt.Println("seed is", seed)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	race, err := maze.Race(ctx, &m, racers)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "search\tstatus\tlength\tcost\texplored\ttime\t\t")
+	var won maze.RaceEntry
+	for _, e := range race.Entries {
+		note := ""
+		switch {
+		case e.Err != nil:
+			note = e.Err.Error()
+		case e.Search == race.Winner:
+			note = "winner"
+			won = e
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%d\t%s\t%s\t\n",
+			e.Search, e.Status, len(e.Solution.Cells), maze.FormatCost(e.Solution.Cost), e.NumExplored, e.Elapsed.Round(time.Microsecond), note)
+	}
+	_ = tw.Flush()
+
+	if race.Winner == "" {
+		fmt.Println("no strategy finished")
+		os.Exit(1)
+	}
+
+	if won.Status == maze.NoSolution {
+		fmt.Println(race.Winner, "won by showing the maze has no solution")
+		return
+	}
+	fmt.Printf("%s won with a %d step solution costing %s\n", race.Winner, len(won.Solution.Cells), maze.FormatCost(won.Solution.Cost))
+
+	if outfile != "" {
+		if err := outputImage(race.Maze, outfile); err != nil {
+			fmt.Println(err)
+		}
+	}
+}
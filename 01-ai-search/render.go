@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"ai-search/maze"
+)
+
+// addViewFlags registers the flags that control how a maze is drawn. The
+// overlays are returned as a list, to be passed to SetOverlays once the
+// flags have been parsed.
+func addViewFlags(fs *flag.FlagSet, v *maze.View) *string {
+	fs.StringVar(&v.Format, "render", "", "how to draw the image ("+strings.Join(maze.RendererNames(), ", ")+"; default from the file name)")
+	fs.IntVar(&v.CellSize, "cell", maze.DefaultCellSize, "cell size in pixels, for png and svg")
+	return fs.String("overlay", "", "comma separated overlays to draw (order, frontier, costs)")
+}
+
+// outputImage draws the maze to filename, or to the terminal if it is "-",
+// using the renderer chosen by the maze's View.
+func outputImage(m *maze.Maze, filename string) error {
+	format := m.View.RendererFor(filename)
+	r, err := maze.LookupRenderer(format)
+	if err != nil {
+		return err
+	}
+
+	if filename == "-" {
+		return r.Render(os.Stdout, m)
+	}
+
+	fmt.Printf("generating %s image %s...\n", format, filename)
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return r.Render(f, m)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"ai-search/maze"
+)
+
+// runReplan implements the replan command, which walks an agent through a
+// maze while scripted events change it, replanning with D* Lite as it goes.
+func runReplan(args []string) {
+	var m maze.Maze
+	var mazeFile, eventsFile, outfile, animationFile, movement string
+	var seed int64
+	var animate bool
+
+	animator := maze.NewAnimator()
+
+	fs := flag.NewFlagSet("replan", flag.ExitOnError)
+	fs.StringVar(&mazeFile, "file", "maze.txt", "maze file")
+	fs.StringVar(&eventsFile, "events", "", "file of scripted events that change the maze during the walk")
+	fs.BoolVar(&animate, "animate", false, "produce animation")
+	fs.StringVar(&animationFile, "animation", "animation.png", "animation file to write (.gif for an animated gif, otherwise an animated png)")
+	fs.IntVar(&animator.Delay, "delay", animator.Delay, "delay between animation frames, in hundredths of a second")
+	fs.IntVar(&animator.Skip, "skip", animator.Skip, "only keep every n'th animation frame")
+	fs.StringVar(&outfile, "image", "image.png", "image of the path walked to write (- for the terminal, empty for none)")
+	fs.StringVar(&movement, "movement", "", "movement model ("+strings.Join(maze.MovementNames(), ", ")+"), overriding the maze file's")
+	fs.Int64Var(&seed, "seed", 1, "random seed for the A* runs the replans are compared with")
+	overlay := addViewFlags(fs, &m.View)
+	addLimitFlags(fs, &m.Limits)
+	_ = fs.Parse(args)
+
+	if err := m.View.SetOverlays(*overlay); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var events []maze.Event
+	if eventsFile != "" {
+		var err error
+		events, err = maze.LoadEvents(eventsFile)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	if movement != "" {
+		var err error
+		m.Movement, err = maze.LookupMovement(movement)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	m.Progress = printProgress
+	if animate {
+		m.Animation = animator
+	}
+
+	if err := m.Load(mazeFile); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	m.SearchType = maze.DSTAR
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if m.Limits.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.Limits.Timeout)
+		defer cancel()
+	}
+
+	startTime := time.Now()
+	plans, err := m.Walk(ctx, events, seed)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "plan\tstep\tat\tchanges\td* lite\ta* from scratch\tplanned cost\t")
+	expanded, fromScratch := 0, 0
+	for i, plan := range plans {
+		cost := "no path"
+		if plan.Reachable {
+			cost = maze.FormatCost(plan.Cost)
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%d,%d\t%d\t%d\t%d\t%s\t\n",
+			i+1, plan.Step, plan.At.Row, plan.At.Col, plan.Changes, plan.Expanded, plan.FromStart, cost)
+		expanded += plan.Expanded
+		fromScratch += plan.FromStart
+	}
+	_ = tw.Flush()
+
+	if len(m.Solution.Cells) > 0 || m.Start == m.Goal {
+		fmt.Println("walked", len(m.Solution.Cells), "steps, cost", maze.FormatCost(m.Solution.Cost))
+		if outfile != "" {
+			if err := outputImage(&m, outfile); err != nil {
+				fmt.Println(err)
+			}
+		}
+	} else {
+		fmt.Println("no solution: the goal was cut off")
+	}
+
+	// the first plan has nothing to reuse, so only replans can save work
+	replanned, replannedFromScratch := expanded-plans[0].Expanded, fromScratch-plans[0].FromStart
+	fmt.Printf("d* lite expanded %d cells over %d plans, a* from scratch would have expanded %d\n", expanded, len(plans), fromScratch)
+	if replannedFromScratch > 0 {
+		fmt.Printf("replanning expanded %d cells instead of %d, saving %.0f%%\n",
+			replanned, replannedFromScratch, 100*float64(replannedFromScratch-replanned)/float64(replannedFromScratch))
+	}
+	fmt.Println("time to walk:", time.Since(startTime))
+
+	if m.Animation != nil {
+		fmt.Println("building animation...")
+		m.Animation.Finish(&m)
+		if err := m.Animation.Write(animationFile); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("wrote %d frames to %s\n", m.Animation.Frames(), animationFile)
+	}
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"ai-search/maze"
+)
+
+// maxImageSide is the most pixels wide or high a rendered image may be; the
+// cell size is shrunk to fit big mazes inside it.
+const maxImageSide = 4096
+
+// flushEvery is how long expansion events may wait in the buffer before
+// being sent, so a fast search isn't slowed down by a flush per cell.
+const flushEvery = 50 * time.Millisecond
+
+// server answers maze solving requests over HTTP.
+type server struct {
+	slots    chan struct{} // holds a token for every solve running
+	maxCells int
+	limits   maze.Limits
+}
+
+// runServe implements the serve command, which solves mazes sent over HTTP.
+//
+// A maze, as text or JSON, is POSTed to /solve, with the search and other
+// options in the query string:
+//
+//	curl --data-binary @maze.txt 'localhost:8080/solve?search=astar&image=svg'
+//
+// The answer is the solution as JSON. Asking for text/event-stream instead
+// streams the search as Server-Sent Events, an event per cell expanded, and
+// ends with a result event holding the same JSON.
+//
+// A browser's EventSource can only send GET, so a maze may also be given in
+// the maze query parameter of a GET, which suits all but the largest mazes:
+//
+//	new EventSource('/solve?search=astar&maze=' + encodeURIComponent(text))
+func runServe(args []string) {
+	var s server
+	var addr string
+	var concurrent int
+
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	fs.StringVar(&addr, "addr", "localhost:8080", "address to listen on")
+	fs.IntVar(&concurrent, "max-solves", 4, "most solves to run at once; more are turned away")
+	fs.IntVar(&s.maxCells, "max-cells", 1000*1000, "largest maze accepted, in cells")
+	fs.IntVar(&s.limits.MaxExpanded, "max-expanded", 0, "stop a search after expanding this many cells (0 for no limit)")
+	fs.DurationVar(&s.limits.Timeout, "timeout", 30*time.Second, "stop a search after this long (0 for no limit)")
+	_ = fs.Parse(args)
+
+	if concurrent < 1 {
+		fmt.Println("-max-solves must be at least 1")
+		os.Exit(1)
+	}
+	s.slots = make(chan struct{}, concurrent)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/solve", s.solve)
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	fmt.Println("listening on", addr)
+	if err := srv.ListenAndServe(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// solveRequest is a maze to solve and how to solve it, read from a request.
+type solveRequest struct {
+	maze     *maze.Maze
+	strategy maze.Strategy
+	route    string
+	order    []string
+	image    string
+}
+
+// requestError is a problem with a request, and the status to answer it with.
+type requestError struct {
+	status int
+	msg    string
+}
+
+func (e *requestError) Error() string {
+	return e.msg
+}
+
+func badRequest(format string, args ...any) error {
+	return &requestError{status: http.StatusBadRequest, msg: fmt.Sprintf(format, args...)}
+}
+
+// solve handles GET and POST /solve.
+func (s *server) solve(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodGet+", "+http.MethodPost)
+		http.Error(w, "POST the maze to solve, or GET with it in the maze parameter", http.StatusMethodNotAllowed)
+		return
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	default:
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "too many solves running, try again shortly", http.StatusServiceUnavailable)
+		return
+	}
+
+	req, err := s.read(w, r)
+	if err != nil {
+		status := http.StatusBadRequest
+		var re *requestError
+		if errors.As(err, &re) {
+			status = re.status
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	var events *eventStream
+	if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		events = newEventStream(w)
+		req.maze.Progress = events.progress
+	}
+
+	result, err := maze.SolveRoute(r.Context(), req.maze, req.strategy, req.route, req.order)
+	if err != nil {
+		if events != nil {
+			events.send("error", map[string]string{"error": err.Error()})
+			events.flush()
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := newSolveResponse(req.maze, result)
+	if req.image != "" {
+		if resp.Image, err = imageURL(req.maze, req.image); err != nil {
+			resp.Image = ""
+			resp.Error = err.Error()
+		}
+	}
+
+	fmt.Printf("%dx%d maze, %s: %s after %d expansions in %s\n",
+		req.maze.Width, req.maze.Height, result.Search, result.Status, result.NumExplored, result.Elapsed.Round(time.Microsecond))
+
+	if events != nil {
+		events.send("result", resp)
+		events.flush()
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(resp)
+}
+
+// read loads the maze in the request body, or for a GET in the maze query
+// parameter, checking it isn't too big, and the options in its query string.
+func (s *server) read(w http.ResponseWriter, r *http.Request) (solveRequest, error) {
+	q := r.URL.Query()
+	// iddfs and idastar keep a transposition table, since without one they
+	// take exponential time on mazes with loops
+	req := solveRequest{
+		maze:  &maze.Maze{Limits: s.limits, MaxCells: s.maxCells, Transpositions: true},
+		route: "waypoints",
+		image: q.Get("image"),
+	}
+	m := req.maze
+
+	name := q.Get("search")
+	if name == "" {
+		name = "astar"
+	}
+	var err error
+	if req.strategy, err = maze.LookupStrategy(name); err != nil {
+		return req, badRequest("%s", err)
+	}
+
+	if movement := q.Get("movement"); movement != "" {
+		if m.Movement, err = maze.LookupMovement(movement); err != nil {
+			return req, badRequest("%s", err)
+		}
+	}
+
+	if route := q.Get("route"); route != "" {
+		req.route = route
+	}
+	if waypoints := q.Get("waypoints"); waypoints != "" {
+		req.order = strings.Split(waypoints, ",")
+	}
+
+	seed := int64(1)
+	if v := q.Get("seed"); v != "" {
+		if seed, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return req, badRequest("seed %q is not a whole number", v)
+		}
+	}
+	m.SetSeed(seed)
+
+	switch req.image {
+	case "", "png", "svg":
+	default:
+		return req, badRequest("image must be png or svg, not %q", req.image)
+	}
+	if v := q.Get("cell"); v != "" {
+		if m.View.CellSize, err = strconv.Atoi(v); err != nil || m.View.CellSize < 1 {
+			return req, badRequest("cell %q is not a positive whole number", v)
+		}
+	}
+	if err := m.View.SetOverlays(q.Get("overlay")); err != nil {
+		return req, badRequest("%s", err)
+	}
+
+	body, err := s.body(w, r)
+	if err != nil {
+		return req, err
+	}
+
+	read := m.Read
+	if strings.Contains(r.Header.Get("Content-Type"), "json") || bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		read = m.ReadJSON
+	}
+	if err := read(bytes.NewReader(body)); err != nil {
+		if errors.Is(err, maze.ErrTooBig) {
+			return req, &requestError{status: http.StatusRequestEntityTooLarge, msg: err.Error()}
+		}
+		return req, badRequest("cannot read maze: %s", err)
+	}
+
+	return req, nil
+}
+
+// body returns the maze text sent with a request: the maze query parameter
+// of a GET, or the body of a POST.
+func (s *server) body(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	if r.Method == http.MethodGet {
+		text := r.URL.Query().Get("maze")
+		if text == "" {
+			return nil, badRequest("no maze given, send it in the maze parameter")
+		}
+		return []byte(text), nil
+	}
+
+	// a maze file takes about a byte per cell, plus its header
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, int64(2*s.maxCells+64<<10)))
+	if err != nil {
+		var tooBig *http.MaxBytesError
+		if errors.As(err, &tooBig) {
+			return nil, &requestError{status: http.StatusRequestEntityTooLarge, msg: fmt.Sprintf("maze is too big, the limit is %d cells", s.maxCells)}
+		}
+		return nil, badRequest("cannot read maze: %s", err)
+	}
+	return body, nil
+}
+
+// solveResponse is the JSON a solve is answered with: the solution, in the
+// form verify reads, and how the search went.
+type solveResponse struct {
+	maze.SolutionFile
+	Status      string        `json:"status"`
+	Steps       int           `json:"steps"`
+	Closest     [2]int        `json:"closest"`
+	Explored    int           `json:"explored"`
+	MaxFrontier int           `json:"max_frontier"`
+	Elapsed     time.Duration `json:"elapsed_ns"`
+	Image       string        `json:"image,omitempty"`
+	Error       string        `json:"error,omitempty"`
+}
+
+func newSolveResponse(m *maze.Maze, result maze.Result) solveResponse {
+	return solveResponse{
+		SolutionFile: m.SolutionFile(result.Solution, result.Search),
+		Status:       result.Status.String(),
+		Steps:        len(result.Solution.Cells),
+		Closest:      [2]int{result.Closest.Row, result.Closest.Col},
+		Explored:     result.NumExplored,
+		MaxFrontier:  result.MaxFrontier,
+		Elapsed:      result.Elapsed,
+	}
+}
+
+// imageURL draws the maze as a data URL, which a browser can show as it is.
+// Cells are shrunk if need be to keep the image a reasonable size.
+func imageURL(m *maze.Maze, format string) (string, error) {
+	size := m.View.CellSize
+	if size == 0 {
+		size = maze.DefaultCellSize
+	}
+	m.View.CellSize = max(1, min(size, maxImageSide/max(m.Width, m.Height)))
+
+	r, err := maze.LookupRenderer(format)
+	if err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+	if err := r.Render(&b, m); err != nil {
+		return "", err
+	}
+
+	mime := "image/png"
+	if format == "svg" {
+		mime = "image/svg+xml"
+	}
+	return "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(b.Bytes()), nil
+}
+
+// eventStream writes Server-Sent Events, buffering them so that a search
+// expanding thousands of cells a second isn't held up flushing each one.
+type eventStream struct {
+	w         *bufio.Writer
+	rc        *http.ResponseController
+	lastFlush time.Time
+}
+
+func newEventStream(w http.ResponseWriter) *eventStream {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	e := &eventStream{w: bufio.NewWriter(w), rc: http.NewResponseController(w)}
+	e.flush()
+	return e
+}
+
+// progress sends what a solver reports as events: started, expanded and
+// notice.
+func (e *eventStream) progress(p maze.Progress) {
+	switch p.Kind {
+	case maze.Started:
+		e.send("started", map[string]string{"search": p.Message})
+	case maze.Expanded:
+		fmt.Fprintf(e.w, "event: expanded\ndata: {\"row\":%d,\"col\":%d,\"explored\":%d}\n\n", p.At.Row, p.At.Col, p.Explored)
+		if time.Since(e.lastFlush) >= flushEvery {
+			e.flush()
+		}
+	case maze.Notice:
+		e.send("notice", map[string]string{"message": p.Message})
+	}
+}
+
+// send writes an event with data as its JSON payload, and sends it at once.
+func (e *eventStream) send(event string, data any) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		payload, _ = json.Marshal(map[string]string{"error": err.Error()})
+		event = "error"
+	}
+	fmt.Fprintf(e.w, "event: %s\ndata: %s\n\n", event, payload)
+	e.flush()
+}
+
+func (e *eventStream) flush() {
+	_ = e.w.Flush()
+	_ = e.rc.Flush()
+	e.lastFlush = time.Now()
+}
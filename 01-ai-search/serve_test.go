@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// smallMaze has 15 cells.
+const smallMaze = "#####\n#A  #\n#  B#\n"
+
+// newTestServer returns a server that solves one maze at a time, of at most
+// maxCells cells.
+func newTestServer(maxCells int) *server {
+	return &server{slots: make(chan struct{}, 1), maxCells: maxCells}
+}
+
+// TestServeLimits checks that mazes over the size limit are turned away with
+// 413, whether they are too big to read at all or only too big once read,
+// and that those within it are solved.
+func TestServeLimits(t *testing.T) {
+	// a body that would take well past the read limit of 2 bytes a cell
+	// plus 64 KiB for a header
+	huge := "# " + strings.Repeat("x", 200<<10) + "\n" + smallMaze
+
+	tests := []struct {
+		name       string
+		method     string
+		maxCells   int
+		maze       string
+		wantStatus int
+	}{
+		{"post", http.MethodPost, 100, smallMaze, http.StatusOK},
+		{"get", http.MethodGet, 100, smallMaze, http.StatusOK},
+		{"post too many cells", http.MethodPost, 10, smallMaze, http.StatusRequestEntityTooLarge},
+		{"get too many cells", http.MethodGet, 10, smallMaze, http.StatusRequestEntityTooLarge},
+		{"post too many bytes", http.MethodPost, 100, huge, http.StatusRequestEntityTooLarge},
+		{"get without a maze", http.MethodGet, 100, "", http.StatusBadRequest},
+		{"put", http.MethodPut, 100, smallMaze, http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := "/solve?search=bfs"
+			var body *strings.Reader
+			if tt.method == http.MethodGet {
+				if tt.maze != "" {
+					target += "&maze=" + url.QueryEscape(tt.maze)
+				}
+				body = strings.NewReader("")
+			} else {
+				body = strings.NewReader(tt.maze)
+			}
+
+			w := httptest.NewRecorder()
+			newTestServer(tt.maxCells).solve(w, httptest.NewRequest(tt.method, target, body))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var resp solveResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Status != "solved" || resp.Steps != 3 {
+				t.Errorf("got %s in %d steps, want solved in 3", resp.Status, resp.Steps)
+			}
+		})
+	}
+}
+
+// TestServeBusy checks that a request arriving while every solve slot is
+// taken is turned away with 503 and a Retry-After, and that the slot it
+// waited on is free again once each request is answered.
+func TestServeBusy(t *testing.T) {
+	s := newTestServer(100)
+	post := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		s.solve(w, httptest.NewRequest(http.MethodPost, "/solve", strings.NewReader(smallMaze)))
+		return w
+	}
+
+	s.slots <- struct{}{} // a solve that is still running
+	w := post()
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status %d with every slot taken, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("busy answer has no Retry-After")
+	}
+	<-s.slots
+
+	for i := range 3 {
+		if w := post(); w.Code != http.StatusOK {
+			t.Fatalf("request %d: status %d, want %d: %s", i+1, w.Code, http.StatusOK, w.Body)
+		}
+	}
+	if n := len(s.slots); n != 0 {
+		t.Errorf("%d slots still taken after every request was answered", n)
+	}
+
+	// a bad request gives its slot back too
+	w = httptest.NewRecorder()
+	s.solve(w, httptest.NewRequest(http.MethodPost, "/solve?search=nope", strings.NewReader(smallMaze)))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status %d for an unknown search, want %d", w.Code, http.StatusBadRequest)
+	}
+	if n := len(s.slots); n != 0 {
+		t.Errorf("%d slots still taken after a bad request", n)
+	}
+}
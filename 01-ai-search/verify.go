@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"ai-search/maze"
+)
+
+// runVerify implements the verify command, which checks a saved solution is
+// a legal path through its maze and compares its cost with the optimal one
+// found by Dijkstra's algorithm.
+func runVerify(args []string) {
+	var m maze.Maze
+	var mazeFile, solutionFile, movement string
+	var requireOptimal bool
+
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	fs.StringVar(&mazeFile, "file", "maze.txt", "maze file")
+	fs.StringVar(&solutionFile, "solution", "solution.json", "solution to check, as saved by -solution or serve, or one action or row,col per line")
+	fs.StringVar(&movement, "movement", "", "movement model ("+strings.Join(maze.MovementNames(), ", ")+"), overriding the solution's and maze file's")
+	fs.BoolVar(&requireOptimal, "optimal", false, "fail unless the solution is also optimal")
+	_ = fs.Parse(args)
+
+	f, err := os.Open(solutionFile)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	sf, err := maze.ReadSolution(f)
+	f.Close()
+	if err != nil {
+		fmt.Printf("error reading %s: %s\n", solutionFile, err)
+		os.Exit(1)
+	}
+
+	// the solution was found with some movement model, which has to be used
+	// to check it, unless one is given here
+	if movement == "" {
+		movement = sf.Movement
+	}
+	if movement != "" {
+		if m.Movement, err = maze.LookupMovement(movement); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	if err := m.Load(mazeFile); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if m.Name != "" {
+		fmt.Println("maze is", m.Name)
+	}
+	if sf.Maze != "" && m.Name != "" && sf.Maze != m.Name {
+		fmt.Printf("warning: solution is for maze %q\n", sf.Maze)
+	}
+
+	solution, err := sf.Solution(&m)
+	if err != nil {
+		fmt.Println("solution is not legal:", err)
+		os.Exit(1)
+	}
+
+	found := ""
+	if sf.Search != "" {
+		found = " found by " + sf.Search
+	}
+	fmt.Printf("solution%s is %d steps, cost %s\n", found, len(solution.Cells), maze.FormatCost(solution.Cost))
+	if len(sf.Waypoints) > 0 {
+		fmt.Println("route is", sf.Route, "through", strings.Join(sf.Waypoints, ","))
+	} else if sf.Route != "" {
+		fmt.Println("route is", sf.Route)
+	}
+
+	if err := m.Check(solution); err != nil {
+		fmt.Println("solution is not legal:", err)
+		os.Exit(1)
+	}
+	fmt.Println("solution is legal")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	optimal, err := referenceCost(ctx, &m, sf.Route, sf.Waypoints)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Println("optimal cost is", maze.FormatCost(optimal))
+
+	extra := solution.Cost - optimal
+	switch {
+	case extra < -1e-9*max(1, optimal):
+		// a legal path cheaper than Dijkstra's means the reference is wrong
+		fmt.Printf("solution is %s cheaper than the reference, which can't be optimal\n", maze.FormatCost(-extra))
+		os.Exit(1)
+	case extra <= 1e-9*max(1, optimal):
+		fmt.Println("solution is optimal")
+	default:
+		fmt.Printf("solution costs %s more than optimal (%.1f%% over)\n", maze.FormatCost(extra), 100*extra/optimal)
+		if requireOptimal {
+			os.Exit(1)
+		}
+	}
+}
+
+// referenceCost finds the optimal cost of solving the maze with Dijkstra's
+// algorithm along the same route as the solution: through the same waypoints
+// in the same order, to the nearest goal, or, if the route isn't known,
+// visiting every goal in the cheapest order.
+func referenceCost(ctx context.Context, m *maze.Maze, route string, order []string) (float64, error) {
+	dijkstra, err := maze.LookupStrategy("dijkstra")
+	if err != nil {
+		return 0, err
+	}
+
+	if route == "" {
+		route = "waypoints"
+		if len(m.Goals) > 1 {
+			route = "all"
+		}
+	}
+
+	result, err := maze.SolveRoute(ctx, m.Fork(), dijkstra, route, order)
+	if err != nil {
+		return 0, err
+	}
+	if result.Status != maze.Solved {
+		return 0, fmt.Errorf("reference search did not solve the maze: %s", result.Status)
+	}
+	return result.Solution.Cost, nil
+}
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"robotsim"
+)
+
+// RunResult holds the statistics for one cleaning run of one room.
+type RunResult struct {
+	Room        string        `json:"room"`
+	Strategy    string        `json:"strategy"`
+	Seed        int64         `json:"seed"`
+	Coverage    float64       `json:"coverage"`
+	Moves       int           `json:"moves"`
+	Efficiency  float64       `json:"efficiency"`
+	Revisits    int           `json:"revisits"`
+	Turns       int           `json:"turns"`
+	Ticks       int           `json:"ticks"`
+	TicksTo95   int           `json:"ticks_to_95"`
+	Obstacles   int           `json:"obstacles"`
+	Redirtied   int           `json:"redirtied"`
+	Charges     int           `json:"charges"`
+	Stranded    bool          `json:"stranded,omitempty"`
+	OutOfCharge bool          `json:"out_of_charge,omitempty"`
+	WallTime    time.Duration `json:"wall_time_ns"`
+}
+
+// evalJob is one run for a worker to do.
+type evalJob struct {
+	index    int
+	file     string
+	room     int
+	strategy robotsim.Strategy
+	seed     int64
+}
+
+// runEvaluate implements the evaluate command, which runs cleaning
+// strategies over rooms and houses without animating them, and reports how
+// they did.
+func runEvaluate(args []string) {
+	fs := flag.NewFlagSet("evaluate", flag.ExitOnError)
+	seeds := fs.Int("seeds", 5, "number of seeds to run each strategy on each room with")
+	seed := fs.Int64("seed", 1, "first seed, incremented for each later one")
+	only := fs.String("algorithm", "", "comma separated list of cleaning algorithms to evaluate (default all)")
+	cat := fs.Bool("cat", false, "add a cat to every room")
+	battery := fs.Float64("battery", 0, "battery capacity in moves, overriding the rooms' (default 500)")
+	workers := fs.Int("workers", runtime.GOMAXPROCS(0), "number of runs to do at once")
+	csvFile := fs.String("csv", "", "write every run to this csv file")
+	jsonFile := fs.String("json", "", "write every run to this json file")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: vacuum evaluate [flags] [room or house files...]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files, _ = filepath.Glob("*.json")
+	}
+
+	names := robotsim.StrategyNames()
+	if *only != "" {
+		names = nil
+		for name := range strings.SplitSeq(*only, ",") {
+			names = append(names, strings.TrimSpace(name))
+		}
+	}
+	var strategies []robotsim.Strategy
+	for _, name := range names {
+		strategy, err := robotsim.LookupStrategy(name)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		strategies = append(strategies, strategy)
+	}
+
+	// every room of every file, with every strategy and seed
+	var jobs []evalJob
+	for _, file := range files {
+		rooms, err := robotsim.NewRooms(file, false)
+		if err != nil {
+			fmt.Printf("%s: %s\n", file, err)
+			os.Exit(1)
+		}
+		for room := range rooms {
+			for _, strategy := range strategies {
+				for i := range *seeds {
+					jobs = append(jobs, evalJob{index: len(jobs), file: file, room: room, strategy: strategy, seed: *seed + int64(i)})
+				}
+			}
+		}
+	}
+
+	results, err := evaluate(jobs, max(1, *workers), *cat, *battery)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	printRanking(results)
+
+	if *csvFile != "" {
+		if err := writeResultsCSV(*csvFile, results); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	if *jsonFile != "" {
+		if err := writeResultsJSON(*jsonFile, results); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+}
+
+// evaluate does the jobs on a pool of workers, each run on a room of its
+// own, returning the results in the order of the jobs.
+func evaluate(jobs []evalJob, workers int, cat bool, battery float64) ([]RunResult, error) {
+	results := make([]RunResult, len(jobs))
+	errs := make([]error, len(jobs))
+
+	queue := make(chan evalJob)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for job := range queue {
+				results[job.index], errs[job.index] = evaluateRun(job, cat, battery)
+			}
+		})
+	}
+
+	for _, job := range jobs {
+		queue <- job
+	}
+	close(queue)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// evaluateRun cleans one room with one strategy and seed.
+func evaluateRun(job evalJob, cat bool, battery float64) (RunResult, error) {
+	rooms, err := robotsim.NewRooms(job.file, false)
+	if err != nil {
+		return RunResult{}, err
+	}
+	room := rooms[job.room]
+	room.SetSeed(job.seed)
+	if cat {
+		room.Cat = robotsim.NewCat(room)
+	}
+	if battery > 0 {
+		room.BatteryCapacity = battery
+	}
+
+	robot := robotsim.NewDockedRobot(room)
+	stats := robotsim.Run(room, robot, job.strategy.New(room, robot))
+
+	name := job.file
+	if len(rooms) > 1 {
+		name = fmt.Sprintf("%s#%d", job.file, job.room+1)
+	}
+
+	return RunResult{
+		Room:        name,
+		Strategy:    job.strategy.Name,
+		Seed:        job.seed,
+		Coverage:    stats.Coverage(),
+		Moves:       stats.Moves,
+		Efficiency:  stats.Efficiency(),
+		Revisits:    stats.Revisits,
+		Turns:       stats.Turns,
+		Ticks:       stats.Ticks,
+		TicksTo95:   stats.TicksTo95,
+		Obstacles:   stats.Obstacles,
+		Redirtied:   stats.Redirtied,
+		Charges:     stats.Charges,
+		Stranded:    stats.Stranded,
+		OutOfCharge: stats.OutOfCharge,
+		WallTime:    stats.Elapsed,
+	}, nil
+}
+
+// ranking is how one strategy did across every room and seed.
+type ranking struct {
+	strategy                                     string
+	runs                                         int
+	coverage, moves, efficiency, revisits, turns float64
+	ticksTo95                                    float64
+	reached95                                    int
+	obstacles, charges                           float64
+	elapsed                                      time.Duration
+}
+
+// printRanking prints a table with one row per strategy, averaging over
+// every room and seed, best first: the most coverage, then the fewest moves.
+func printRanking(results []RunResult) {
+	byStrategy := make(map[string]*ranking)
+	var ranks []*ranking
+	for _, r := range results {
+		rank, ok := byStrategy[r.Strategy]
+		if !ok {
+			rank = &ranking{strategy: r.Strategy}
+			byStrategy[r.Strategy] = rank
+			ranks = append(ranks, rank)
+		}
+
+		rank.runs++
+		rank.coverage += r.Coverage
+		rank.moves += float64(r.Moves)
+		rank.efficiency += r.Efficiency
+		rank.revisits += float64(r.Revisits)
+		rank.turns += float64(r.Turns)
+		rank.obstacles += float64(r.Obstacles)
+		rank.charges += float64(r.Charges)
+		rank.elapsed += r.WallTime
+		if r.TicksTo95 >= 0 {
+			rank.ticksTo95 += float64(r.TicksTo95)
+			rank.reached95++
+		}
+	}
+
+	for _, rank := range ranks {
+		n := float64(rank.runs)
+		rank.coverage /= n
+		rank.moves /= n
+		rank.efficiency /= n
+		rank.revisits /= n
+		rank.turns /= n
+		rank.obstacles /= n
+		rank.charges /= n
+		rank.elapsed /= time.Duration(rank.runs)
+		if rank.reached95 > 0 {
+			rank.ticksTo95 /= float64(rank.reached95)
+		}
+	}
+
+	slices.SortStableFunc(ranks, func(a, b *ranking) int {
+		if c := cmp.Compare(b.coverage, a.coverage); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.moves, b.moves)
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "rank\tstrategy\truns\tcoverage\tmoves\tefficiency\trevisits\tturns\tticks to 95%\tobstacles\tcharges\ttime\t")
+	for i, rank := range ranks {
+		to95 := "never"
+		if rank.reached95 > 0 {
+			to95 = fmt.Sprintf("%.1f", rank.ticksTo95)
+			if rank.reached95 < rank.runs {
+				to95 += fmt.Sprintf(" (%d/%d)", rank.reached95, rank.runs)
+			}
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%d\t%.2f%%\t%.1f\t%.2f\t%.1f\t%.1f\t%s\t%.1f\t%.1f\t%s\t\n",
+			i+1, rank.strategy, rank.runs, rank.coverage, rank.moves, rank.efficiency, rank.revisits, rank.turns,
+			to95, rank.obstacles, rank.charges, rank.elapsed.Round(time.Microsecond))
+	}
+
+	_ = w.Flush()
+}
+
+func writeResultsCSV(filename string, results []RunResult) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	_ = w.Write([]string{
+		"room", "strategy", "seed", "coverage", "moves", "efficiency", "revisits", "turns",
+		"ticks", "ticks_to_95", "obstacles", "redirtied", "charges", "stranded", "out_of_charge",
+		"wall_time_ns",
+	})
+
+	for _, r := range results {
+		_ = w.Write([]string{
+			r.Room,
+			r.Strategy,
+			strconv.FormatInt(r.Seed, 10),
+			strconv.FormatFloat(r.Coverage, 'f', -1, 64),
+			strconv.Itoa(r.Moves),
+			strconv.FormatFloat(r.Efficiency, 'f', -1, 64),
+			strconv.Itoa(r.Revisits),
+			strconv.Itoa(r.Turns),
+			strconv.Itoa(r.Ticks),
+			strconv.Itoa(r.TicksTo95),
+			strconv.Itoa(r.Obstacles),
+			strconv.Itoa(r.Redirtied),
+			strconv.Itoa(r.Charges),
+			strconv.FormatBool(r.Stranded),
+			strconv.FormatBool(r.OutOfCharge),
+			strconv.FormatInt(r.WallTime.Nanoseconds(), 10),
+		})
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func writeResultsJSON(filename string, results []RunResult) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
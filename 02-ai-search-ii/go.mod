@@ -1,3 +1,7 @@
 module vacuum
 
 go 1.25.0
+
+require robotsim v0.0.0
+
+replace robotsim => ../robotsim
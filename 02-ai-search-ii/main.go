@@ -2,40 +2,52 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"robotsim"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "evaluate" {
+		runEvaluate(os.Args[2:])
+		return
+	}
+
 	var configFile, algorithm string
 	var animate, cat bool
+	var battery float64
 
 	flag.StringVar(&configFile, "file", "empty.json", "configuration file")
-	flag.StringVar(&algorithm, "algorithm", "snake", "cleaning algorithm")
+	flag.StringVar(&algorithm, "algorithm", "snake", "cleaning algorithm ("+strings.Join(robotsim.StrategyNames(), ", ")+")")
 	flag.BoolVar(&animate, "animate", true, "animate while cleaning")
 	flag.BoolVar(&cat, "cat", false, "add a cat to the room")
+	flag.Float64Var(&battery, "battery", 0, "battery capacity in moves, overriding the room's (default 500)")
 	flag.Parse()
 
-	room := NewRoom(configFile, animate)
-	if cat {
-		room.Cat = NewCat(room)
+	strategy, err := robotsim.LookupStrategy(algorithm)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
 
-	// get a robot
-	robot := NewRobot(1, 1)
-
-	// assign a cleaning algorithm
-	switch algorithm {
-	case "random":
-		robot.CleanRoom = CleanRoomRandomWalk
-	case "slam":
-		robot.CleanRoom = CleanRoomSlam
-	case "spiral":
-		robot.CleanRoom = CleanSpiralPattern
-	case "snake":
-		robot.CleanRoom = CleanRoomSnake
-	default:
-		robot.CleanRoom = CleanRoomSnake
+	room, err := robotsim.NewRoom(configFile, animate)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
+	if cat {
+		room.Cat = robotsim.NewCat(room)
+	}
+	if battery > 0 {
+		room.BatteryCapacity = battery
+	}
+
+	// get a robot, waiting on its dock
+	robot := robotsim.NewDockedRobot(room)
 
 	// clean the room
-	robot.CleanRoom(room, robot)
+	stats := robotsim.Run(room, robot, strategy.New(room, robot))
+	robotsim.DisplaySummary(room, robot, stats)
 }
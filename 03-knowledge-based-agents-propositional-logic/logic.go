@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"time"
+
+	"robotsim"
 )
 
 type PersonStatus struct {
@@ -152,19 +154,19 @@ func (world *LogicalWorld) DetermineCleaningPriority() []string {
 
 // RobotWithLogic is a type for a robot with logic
 type RobotWithLogic struct {
-	*Robot // embedding the original robot
-	World  *LogicalWorld
+	*robotsim.Robot // embedding the original robot
+	World           *LogicalWorld
 }
 
 // NewRobotWithLogic is a factory method for robot with logic
 func NewRobotWithLogic(startX, startY int) *RobotWithLogic {
 	return &RobotWithLogic{
-		Robot: NewRobot(startX, startY),
+		Robot: robotsim.NewRobot(startX, startY),
 		World: NewLogicalWorld(),
 	}
 }
 
-func (robot *RobotWithLogic) ScanHouseWithLogic(house *House) map[string]int {
+func (robot *RobotWithLogic) ScanHouseWithLogic(house *robotsim.House) map[string]int {
 	// create a map which maps room indices to room names
 	roomNameToIndex := make(map[string]int)
 
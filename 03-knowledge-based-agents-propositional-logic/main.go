@@ -3,44 +3,71 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
+	"strings"
 	"time"
+
+	"robotsim"
 )
 
 func main() {
 	var configFile, algorithm string
 	var animate, cat, isHouse, useLogic bool
+	var battery float64
 
 	flag.StringVar(&configFile, "file", "empty.json", "configuration file")
-	flag.StringVar(&algorithm, "algorithm", "snake", "cleaning algorithm")
+	flag.StringVar(&algorithm, "algorithm", "snake", "cleaning algorithm ("+strings.Join(robotsim.StrategyNames(), ", ")+")")
 	flag.BoolVar(&animate, "animate", true, "animate while cleaning")
 	flag.BoolVar(&cat, "cat", false, "add a cat to the room")
 	flag.BoolVar(&isHouse, "house", false, "config file has multiple rooms")
 	flag.BoolVar(&useLogic, "logic", false, "use propositional logic for cleaning decisions")
+	flag.Float64Var(&battery, "battery", 0, "battery capacity in moves, overriding the rooms' (default 500)")
 	flag.Parse()
 
-	var house *House
+	strategy, err := robotsim.LookupStrategy(algorithm)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var house *robotsim.House
 
 	if !isHouse {
 		// if not a house, we just have one room. create a house and assing one room to it
 		// this way we can use the same loop for houses and for individual rooms
-		var rooms []*Room
+		var rooms []*robotsim.Room
 
 		// get a room from json config
-		room := NewRoom(configFile, animate)
+		room, err := robotsim.NewRoom(configFile, animate)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
 		rooms = append(rooms, room)
 
-		var h House
+		var h robotsim.House
 		h.Rooms = rooms
 		house = &h
 	} else {
 		// we are doing a complete house. just get a house from json config
-		house = NewHouse(configFile, animate)
+		house, err = robotsim.NewHouse(configFile, animate)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
 	}
 
 	// add cats to rooms if necessary
 	if cat {
 		for _, room := range house.Rooms {
-			room.Cat = NewCat(room)
+			room.Cat = robotsim.NewCat(room)
+		}
+	}
+
+	// swap in a different battery if asked to
+	if battery > 0 {
+		for _, room := range house.Rooms {
+			room.BatteryCapacity = battery
 		}
 	}
 
@@ -51,9 +78,6 @@ func main() {
 		fmt.Println("using propositional logic for cleaning decisions")
 		robot := NewRobotWithLogic(1, 1)
 
-		// assign a cleaning algorithm
-		setUpAlgorithm(algorithm, robot.Robot)
-
 		// scan the house
 		roomNameToIndex := robot.ScanHouseWithLogic(house)
 
@@ -96,26 +120,24 @@ func main() {
 			// get the room from house.Rooms by index
 			room := house.Rooms[roomIndex]
 
-			// reset robot position to 1,1
-			robot.Position = Point{X: 1, Y: 1}
-			robot.Path = []Point{{X: 1, Y: 1}}
+			// start the robot from the room's dock
+			room.PutOnDock(robot.Robot)
 
 			// clean the room
-			robot.CleanRoom(room, robot.Robot)
+			stats := robotsim.Run(room, robot.Robot, strategy.New(room, robot.Robot))
+			robotsim.DisplaySummary(room, robot.Robot, stats)
 			roomCount++
 		}
 
 	} else {
 		// use the original cleaning approach without propositional logic, and for multiple rooms
 		for _, room := range house.Rooms {
-			// get a robot
-			robot := NewRobot(1, 1)
-
-			// assign a cleaning algorithm
-			setUpAlgorithm(algorithm, robot)
+			// get a robot, waiting on its dock
+			robot := robotsim.NewDockedRobot(room)
 
 			// clean the room
-			robot.CleanRoom(room, robot)
+			stats := robotsim.Run(room, robot, strategy.New(room, robot))
+			robotsim.DisplaySummary(room, robot, stats)
 			roomCount++
 		}
 	}
@@ -123,18 +145,3 @@ func main() {
 	fmt.Println()
 	fmt.Printf("all done. cleaned a total of %d room(s)\n", roomCount)
 }
-
-func setUpAlgorithm(algorithm string, robot *Robot) {
-	switch algorithm {
-	case "random":
-		robot.CleanRoom = CleanRoomRandomWalk
-	case "slam":
-		robot.CleanRoom = CleanRoomSlam
-	case "spiral":
-		robot.CleanRoom = CleanSpiralPattern
-	case "snake":
-		robot.CleanRoom = CleanRoomSnake
-	default:
-		robot.CleanRoom = CleanRoomSnake
-	}
-}
@@ -1,4 +1,4 @@
-package main
+package robotsim
 
 import (
 	"container/heap"
@@ -49,7 +49,15 @@ func (pq *PriorityQueue) Update(item *PQItem, priority float64) {
 	heap.Fix(pq, item.index)
 }
 
+// AStar finds the shortest path from start to goal, including both, or an
+// empty path if there is none.
 func AStar(room *Room, start, goal Point) []Point {
+	return AStarAvoiding(room, start, goal, nil)
+}
+
+// AStarAvoiding is AStar, keeping clear of any cell avoid reports true for,
+// such as one the cat is sitting in.
+func AStarAvoiding(room *Room, start, goal Point, avoid func(Point) bool) []Point {
 	if !room.IsValid(start.X, start.Y) || !room.IsValid(goal.X, goal.Y) {
 		return []Point{}
 	}
@@ -68,9 +76,9 @@ func AStar(room *Room, start, goal Point) []Point {
 	fScore[start] = heuristic(start, goal)
 
 	startItem := &PQItem{
-		point: start,
+		point:    start,
 		priority: fScore[start],
-		index: 0,
+		index:    0,
 	}
 	heap.Push(&pq, startItem)
 	openSetItems[start] = startItem
@@ -95,7 +103,7 @@ func AStar(room *Room, start, goal Point) []Point {
 			neighbor := Point{X: current.X + dir[0], Y: current.Y + dir[1]}
 
 			// skip if neighbor is invalid or in closed set
-			if !room.IsValid(neighbor.X, neighbor.Y) || closedSet[neighbor] {
+			if !room.IsValid(neighbor.X, neighbor.Y) || closedSet[neighbor] || (avoid != nil && avoid(neighbor)) {
 				continue
 			}
 
@@ -114,7 +122,7 @@ func AStar(room *Room, start, goal Point) []Point {
 				} else {
 					// add new point to the priority queue
 					neighborItem := &PQItem{
-						point: neighbor,
+						point:    neighbor,
 						priority: fScore[neighbor],
 					}
 					heap.Push(&pq, neighborItem)
@@ -143,4 +151,4 @@ func reconstructPath(cameFrom map[Point]Point, current Point) []Point {
 		current = prev
 	}
 	return path
-}
\ No newline at end of file
+}
@@ -0,0 +1,50 @@
+package robotsim
+
+const (
+	defaultBatteryCapacity = 500  // enough for a few hundred cells between charges
+	moveCharge             = 1.0  // charge used moving one cell
+	turnCharge             = 0.5  // charge used turning to face a new direction
+	chargeRate             = 25.0 // charge put back each tick on the dock
+	homeMargin             = 3.0  // charge kept spare for the way back to the dock, for going around the cat
+)
+
+// Battery is the robot's charge. Moving and turning use it up, and the robot
+// has to get back to its dock to charge it.
+type Battery struct {
+	Capacity float64
+	Charge   float64
+}
+
+// NewBattery returns a full battery holding capacity.
+func NewBattery(capacity float64) *Battery {
+	return &Battery{Capacity: capacity, Charge: capacity}
+}
+
+// Level returns the charge left, as a percentage of the capacity.
+func (b *Battery) Level() float64 {
+	return b.Charge / b.Capacity * 100
+}
+
+// Full reports whether the battery is charged full.
+func (b *Battery) Full() bool {
+	return b.Charge >= b.Capacity
+}
+
+// Use takes amount from the charge.
+func (b *Battery) Use(amount float64) {
+	b.Charge = max(0, b.Charge-amount)
+}
+
+// Recharge charges the battery for one tick.
+func (b *Battery) Recharge() {
+	b.Charge = min(b.Capacity, b.Charge+chargeRate)
+}
+
+// moveCost is the charge it takes to move from one cell to the next one,
+// facing heading beforehand.
+func moveCost(from, to Point, heading float64) float64 {
+	if !sameHeading(headingTo(from, to), heading) {
+		return moveCharge + turnCharge
+	}
+	return moveCharge
+}
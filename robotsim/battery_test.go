@@ -0,0 +1,181 @@
+package robotsim
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMoveCost(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to Point
+		heading  float64
+		want     float64
+	}{
+		{"straight on east", Point{1, 1}, Point{2, 1}, 0, moveCharge},
+		{"straight on south", Point{1, 1}, Point{1, 2}, 90, moveCharge},
+		{"straight on north", Point{1, 2}, Point{1, 1}, 270, moveCharge},
+		{"north as -90", Point{1, 2}, Point{1, 1}, -90, moveCharge},
+		{"east as 360", Point{1, 1}, Point{2, 1}, 360, moveCharge},
+		{"turn south", Point{1, 1}, Point{1, 2}, 0, moveCharge + turnCharge},
+		{"turn around", Point{2, 1}, Point{1, 1}, 0, moveCharge + turnCharge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := moveCost(tt.from, tt.to, tt.heading); got != tt.want {
+				t.Errorf("moveCost(%v, %v, %v) = %v, want %v", tt.from, tt.to, tt.heading, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBatteryDrain moves the robot by hand and checks what each move takes
+// out of the battery: a move, and a turn on top when it changes direction.
+func TestBatteryDrain(t *testing.T) {
+	room := testRoom(t, 8, 8)
+	robot := NewDockedRobot(room)
+	strategy, _ := LookupStrategy("snake")
+	sim := NewSimulation(room, robot, strategy.New(room, robot))
+
+	steps := []struct {
+		to   Point
+		want float64 // charge used
+	}{
+		{Point{2, 1}, moveCharge},
+		{Point{3, 1}, moveCharge},
+		{Point{3, 2}, moveCharge + turnCharge},
+		{Point{3, 3}, moveCharge},
+		{Point{2, 3}, moveCharge + turnCharge},
+		{Point{2, 2}, moveCharge + turnCharge},
+	}
+
+	charge := robot.Battery.Charge
+	for _, step := range steps {
+		sim.move(step.to)
+		if used := charge - robot.Battery.Charge; used != step.want {
+			t.Errorf("moving to %v used %v, want %v", step.to, used, step.want)
+		}
+		charge = robot.Battery.Charge
+	}
+	if sim.stats.Turns != 3 {
+		t.Errorf("%d turns, want 3", sim.stats.Turns)
+	}
+}
+
+func TestBattery(t *testing.T) {
+	b := NewBattery(100)
+	if !b.Full() || b.Level() != 100 {
+		t.Fatalf("new battery at %v%%", b.Level())
+	}
+
+	b.Use(30)
+	if b.Full() || b.Level() != 70 {
+		t.Errorf("battery at %v%% after using 30 of 100", b.Level())
+	}
+	b.Use(200)
+	if b.Charge != 0 {
+		t.Errorf("charge %v after using more than was left, want 0", b.Charge)
+	}
+
+	for range 3 {
+		b.Recharge()
+	}
+	if b.Charge != 3*chargeRate {
+		t.Errorf("charge %v after 3 ticks charging, want %v", b.Charge, 3*chargeRate)
+	}
+	for range 10 {
+		b.Recharge()
+	}
+	if !b.Full() || b.Charge != b.Capacity {
+		t.Errorf("charge %v after charging a long time, want %v", b.Charge, b.Capacity)
+	}
+}
+
+// TestChargeCycles cleans a room with batteries of different sizes: one
+// lasting the whole clean, smaller ones that reach every cell but need
+// charging along the way, and one that can't reach the far corner.
+func TestChargeCycles(t *testing.T) {
+	tests := []struct {
+		capacity     float64
+		wantCharges  bool // whether the robot has to stop to charge
+		wantStranded bool // whether the far end is out of the battery's reach
+	}{
+		{capacity: defaultBatteryCapacity},
+		{capacity: 80, wantCharges: true},
+		{capacity: 50, wantCharges: true},
+		{capacity: 30, wantCharges: true, wantStranded: true},
+	}
+
+	for _, tt := range tests {
+		for _, name := range StrategyNames() {
+			t.Run(fmt.Sprintf("%v/%s", tt.capacity, name), func(t *testing.T) {
+				room := testRoom(t, 12, 12)
+				room.BatteryCapacity = tt.capacity
+				robot := NewDockedRobot(room)
+				strategy, _ := LookupStrategy(name)
+				stats := Run(room, robot, strategy.New(room, robot))
+
+				if stats.OutOfCharge {
+					t.Fatalf("ran out of charge at %v", robot.Position)
+				}
+				if got := stats.Charges > 0; got != tt.wantCharges {
+					t.Errorf("charged %d times", stats.Charges)
+				}
+				if stats.ChargingTicks < stats.Charges {
+					t.Errorf("charged %d times in %d ticks", stats.Charges, stats.ChargingTicks)
+				}
+				if stats.Stranded != tt.wantStranded {
+					t.Errorf("stranded %v, want %v", stats.Stranded, tt.wantStranded)
+				}
+				if !tt.wantStranded && stats.Coverage() != 100 {
+					t.Errorf("coverage %.2f%%, want 100%%", stats.Coverage())
+				}
+				if robot.Position != room.Dock {
+					t.Errorf("finished at %v, want the dock at %v", robot.Position, room.Dock)
+				}
+			})
+		}
+	}
+}
+
+// TestNoStrandingWithCat runs every strategy with the cat in rooms the
+// battery reaches every corner of, where the robot should never give up on
+// a cell or run flat, however the cat gets in the way.
+func TestNoStrandingWithCat(t *testing.T) {
+	rooms := []struct {
+		name     string
+		capacity float64
+		room     func(tb testing.TB) *Room
+	}{
+		{"furnished", defaultBatteryCapacity, func(tb testing.TB) *Room {
+			return testRoom(tb, 30, 30, table(5, 5, 6, 3), table(18, 12, 4, 8), table(8, 20, 10, 2))
+		}},
+		{"small battery", 150, func(tb testing.TB) *Room {
+			return testRoom(tb, 20, 15, table(6, 4, 3, 5), table(12, 9, 5, 2))
+		}},
+		{"corridors", 120, func(tb testing.TB) *Room {
+			return testRoom(tb, 24, 12, table(4, 1, 2, 8), table(10, 3, 2, 8), table(16, 1, 2, 8))
+		}},
+	}
+
+	for _, r := range rooms {
+		for _, name := range StrategyNames() {
+			for seed := range int64(5) {
+				t.Run(fmt.Sprintf("%s/%s/seed %d", r.name, name, seed), func(t *testing.T) {
+					room := r.room(t)
+					room.SetSeed(seed)
+					room.Cat = NewCat(room)
+					room.BatteryCapacity = r.capacity
+					robot := NewDockedRobot(room)
+					strategy, _ := LookupStrategy(name)
+					stats := Run(room, robot, strategy.New(room, robot))
+
+					if stats.Stranded || stats.OutOfCharge {
+						t.Errorf("stranded %v, out of charge %v at %v, %.2f%% clean", stats.Stranded, stats.OutOfCharge, robot.Position, stats.Coverage())
+					}
+				})
+			}
+		}
+	}
+}
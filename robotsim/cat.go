@@ -0,0 +1,96 @@
+package robotsim
+
+type Cat struct {
+	Position   Point
+	Active     bool
+	StopTimer  int
+	Path       []Point
+	DirectionX int
+	DirectionY int
+}
+
+func NewCat(room *Room) *Cat {
+	var startX, startY int
+	for {
+		startX = room.Rand.Intn(room.Width-4) + 2
+		startY = room.Rand.Intn(room.Height-4) + 2
+
+		if !room.Grid[startX][startY].Obstacle {
+			break
+		}
+	}
+
+	return &Cat{
+		Position:   Point{X: startX, Y: startY},
+		Active:     true,
+		StopTimer:  0,
+		Path:       []Point{{X: startX, Y: startY}},
+		DirectionX: room.Rand.Intn(3) - 1,
+		DirectionY: room.Rand.Intn(3) - 1,
+	}
+}
+
+// Tick moves the cat one step in the direction it is heading, now and then
+// stopping for a while or changing direction. It won't walk into furniture,
+// walls or the robot, and walks dirt back into any clean cell it steps on.
+func (cat *Cat) Tick(sim *Simulation) {
+	room := sim.Room
+
+	// a stopped cat stays put until its timer runs out
+	if cat.StopTimer > 0 {
+		cat.StopTimer--
+		return
+	}
+
+	// chance for the cat to stop
+	if room.Rand.Float64() < catStopProbability {
+		cat.StopTimer = catStopDuration
+		return
+	}
+
+	// change direction randomly
+	if room.Rand.Float64() < 0.2 {
+		cat.DirectionX = room.Rand.Intn(3) - 1
+		cat.DirectionY = room.Rand.Intn(3) - 1
+	}
+
+	// make sure cat doesnt just stay still when moving
+	if cat.DirectionX == 0 && cat.DirectionY == 0 {
+		cat.DirectionX = room.Rand.Intn(3) - 1
+		if cat.DirectionX == 0 {
+			cat.DirectionY = room.Rand.Intn(2)*2 - 1
+		} else {
+			cat.DirectionY = room.Rand.Intn(3) - 1
+		}
+	}
+
+	// calculate the new position
+	newPosition := Point{X: cat.Position.X + cat.DirectionX, Y: cat.Position.Y + cat.DirectionY}
+
+	// turn around rather than bump into things, heading back the way it
+	// came from the next tick
+	if !room.IsValid(newPosition.X, newPosition.Y) || newPosition == sim.Robot.Position {
+		cat.DirectionX, cat.DirectionY = -cat.DirectionX, -cat.DirectionY
+		return
+	}
+
+	// update cat position
+	cat.Position = newPosition
+	cat.Path = append(cat.Path, cat.Position)
+
+	if room.Grid[newPosition.X][newPosition.Y].Cleaned {
+		room.Grid[newPosition.X][newPosition.Y].Cleaned = false
+		room.Grid[newPosition.X][newPosition.Y].Type = "dirty"
+		room.CleanedCellCount--
+		sim.redirty(newPosition)
+	}
+}
+
+func IsAdjacentToCat(robot *Robot, cat *Cat) bool {
+	if cat != nil {
+		dx := abs(robot.Position.X - cat.Position.X)
+		dy := abs(robot.Position.Y - cat.Position.Y)
+		return dx <= 1 && dy <= 1
+	}
+	return false
+}
@@ -0,0 +1,3 @@
+module robotsim
+
+go 1.25.0
@@ -0,0 +1,174 @@
+package robotsim
+
+import "math"
+
+// maxStuckCount is how many short walks in a row the random walk takes
+// before heading for the nearest dirty cell instead.
+const maxStuckCount = 5
+
+// randomWalk moves in straight lines at random angles until it hits an
+// obstacle, heading for dirt with A* now and then, and whenever it keeps
+// getting stuck.
+type randomWalk struct {
+	maxMoves   int
+	stuckCount int
+	walking    bool // the last plan was a straight line
+	before     int  // moves made before the last plan
+}
+
+func newRandomWalk(room *Room, robot *Robot) CleaningStrategy {
+	return &randomWalk{maxMoves: room.Width * room.Height * 5}
+}
+
+func (w *randomWalk) Next(room *Room, robot *Robot) ([]Point, bool) {
+	moveCount := len(robot.Path) - 1
+	walked := w.walking
+	w.walking = false
+
+	if walked {
+		// if we didnt move very much, increment stuck count and possibly
+		// use A* to find a path to the nearest dirty cell
+		if moveCount-w.before < 3 {
+			w.stuckCount++
+			if w.stuckCount >= maxStuckCount {
+				w.stuckCount = 0
+				if path := pathToNearestDirtyCell(room, robot); len(path) > 0 {
+					return path, true
+				}
+			}
+		} else {
+			w.stuckCount = 0
+		}
+	}
+
+	// add some adaptive behaviour. scan for dirty cells every once in awhile
+	if moveCount%20 == 0 && room.Rand.Float64() < 0.3 { // 30% chance to target a specific dirty area
+		if path := pathToNearestDirtyCell(room, robot); len(path) > 0 {
+			return path, true
+		}
+	}
+
+	if moveCount >= w.maxMoves || room.CleanedCellCount >= room.CleanableCellCount {
+		return nil, false
+	}
+
+	// generate a random angle in radians
+	angle := room.Rand.Float64() * 2 * math.Pi
+
+	// calculate a direction vector based on angle
+	dx := math.Cos(angle)
+	dy := math.Sin(angle)
+
+	// use bresenham's line algorithm to move in that direction; the robot
+	// stops when it hits an obstacle
+	maxDistance := math.Max(float64(room.Width), float64(room.Height)) * 2
+	startX, startY := robot.Position.X, robot.Position.Y
+	points := bresenhamLine(startX, startY, startX+int(dx*maxDistance), startY+int(dy*maxDistance))
+
+	w.walking = true
+	w.before = moveCount
+	return points[1:], true
+}
+
+// pathToNearestDirtyCell finds a path to the nearest dirty cell with A*,
+// not including the robot's own cell.
+func pathToNearestDirtyCell(room *Room, robot *Robot) []Point {
+	dirtyCell := findNearestDirtyCell(room, robot.Position)
+	if dirtyCell.X == -1 && dirtyCell.Y == -1 {
+		return nil
+	}
+
+	path := AStar(room, robot.Position, dirtyCell)
+	if len(path) <= 1 {
+		return nil
+	}
+	return path[1:]
+}
+
+func bresenhamLine(x0, y0, x1, y1 int) []Point {
+	// initialize a slice to store all points on the line
+	var points []Point
+
+	// calculate the absolute difference between the endpoints
+	dx := abs(x1 - x0)
+	dy := abs(y1 - y0)
+
+	// determine the direction of movement, along each axis
+	sx := -1
+	if x0 < x1 {
+		sx = 1
+	}
+	sy := -1
+	if y0 < y1 {
+		sy = 1
+	}
+
+	// calculate the inicial error value
+	err := dx - dy
+
+	// for loop until we reach the endpoint
+	for {
+		// add the current point to our result
+		points = append(points, Point{X: x0, Y: y0})
+
+		// check to see if we've reached the endpoint
+		if x0 == x1 && y0 == y1 {
+			break
+		}
+
+		// calculate the error for the next step
+		e2 := 2 * err
+
+		// if moving in the x direction would keep us closer to the ideal line
+		if e2 > -dy {
+			// if we've reached the endpoint, stop
+			if x0 == x1 {
+				break
+			}
+			// update the error and move in the x direction
+			err = dy
+			x0 += sx
+		}
+
+		// if moving in the y direction would keep us closed to the ideal line
+		if e2 < dx {
+			// if we've reached the endpoint, stop
+			if y0 == y1 {
+				break
+			}
+			err += dx
+			y0 += sy
+		}
+	}
+
+	// return poins
+	return points
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return x * -1
+	}
+	return x
+}
+
+func findNearestDirtyCell(room *Room, position Point) Point {
+	var nearestCell Point = Point{X: -1, Y: -1}
+	minDistance := math.MaxFloat64
+
+	for i := 1; i < room.Width-1; i++ {
+		for j := 1; j < room.Height-1; j++ {
+			if room.Grid[i][j].Cleaned || room.Grid[i][j].Obstacle {
+				continue
+			}
+
+			distance := heuristic(position, Point{X: i, Y: j})
+			if distance < minDistance {
+				minDistance = distance
+				nearestCell = Point{X: i, Y: j}
+			}
+		}
+	}
+
+	return nearestCell
+}
@@ -1,4 +1,4 @@
-package main
+package robotsim
 
 var directions = [][]int{
 	{0, -1}, // north
@@ -10,9 +10,9 @@ var directions = [][]int{
 type Robot struct {
 	Position             Point
 	Path                 []Point
-	CleanRoom            func(*Room, *Robot)
 	Direction            float64
 	ObstaclesEncountered map[string]bool
+	Battery              *Battery
 }
 
 func NewRobot(startX, startY int) *Robot {
@@ -23,9 +23,17 @@ func NewRobot(startX, startY int) *Robot {
 			Y: startY,
 		}},
 		ObstaclesEncountered: make(map[string]bool),
+		Battery:              NewBattery(defaultBatteryCapacity),
 	}
 }
 
+// NewDockedRobot returns a robot on the room's dock, ready to clean it.
+func NewDockedRobot(room *Room) *Robot {
+	robot := NewRobot(room.Dock.X, room.Dock.Y)
+	room.PutOnDock(robot)
+	return robot
+}
+
 func Clean(robot *Robot, room *Room) {
 	x, y := robot.Position.X, robot.Position.Y
 
@@ -49,9 +57,9 @@ func CheckAdjacentObstacles(robot *Robot, room *Room) {
 }
 
 func RecordObstacle(robot *Robot, room *Room, x, y int) {
-	if x >= 0 && x < room.Width && y >= 0 && y <= room.Height && room.Grid[x][y].Obstacle {
+	if x >= 0 && x < room.Width && y >= 0 && y < room.Height && room.Grid[x][y].Obstacle {
 		if room.Grid[x][y].Type == "furniture" && room.Grid[x][y].ObstacleName != "" {
 			robot.ObstaclesEncountered[room.Grid[x][y].ObstacleName] = true
 		}
 	}
-}
\ No newline at end of file
+}
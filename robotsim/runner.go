@@ -0,0 +1,577 @@
+package robotsim
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+const (
+	maxCatWait = 3   // ticks the robot waits for the cat before going around it
+	maxReplans = 100 // plans the robot may throw away in one tick before waiting
+)
+
+// Stats is how a cleaning run went.
+type Stats struct {
+	Ticks          int
+	Moves          int
+	Cleaned        int
+	Cleanable      int
+	Revisits       int  // moves into a cell the robot had already been in
+	Turns          int  // moves in a different direction to the one before
+	TicksTo95      int  // ticks until 95% of the room was first clean, or -1 if it never was
+	Obstacles      int  // pieces of furniture the robot came across
+	Waits          int  // ticks the robot spent waiting for the cat to move
+	Redirtied      int  // times the cat walked dirt into a clean cell
+	RedirtiedCells int  // cells the cat dirtied at least once
+	LeftDirty      int  // cells the cat dirtied that were still dirty at the end
+	Charges        int  // times the robot went back to its dock to charge
+	ChargingTicks  int  // ticks spent charging
+	Stranded       bool // the robot stopped early, with cells left too far from the dock for its battery
+	OutOfCharge    bool // the robot ran flat before it could get back to its dock
+	Elapsed        time.Duration
+}
+
+// Coverage returns the percentage of cleanable cells that were cleaned, or 0
+// for a room with nothing to clean.
+func (s Stats) Coverage() float64 {
+	if s.Cleanable == 0 {
+		return 0
+	}
+	return float64(s.Cleaned) / float64(s.Cleanable) * 100
+}
+
+// Efficiency returns the cells cleaned per move, or 0 if the robot never
+// moved.
+func (s Stats) Efficiency() float64 {
+	if s.Moves == 0 {
+		return 0
+	}
+	return float64(s.Cleaned) / float64(s.Moves)
+}
+
+// Agent is anything that acts in the room: the robot, the cat, and whatever
+// else joins them. Every agent gets one turn each tick.
+type Agent interface {
+	Tick(sim *Simulation)
+}
+
+// Simulation runs a room in discrete ticks, advancing every agent in turn,
+// until the robot has finished cleaning.
+type Simulation struct {
+	Room  *Room
+	Robot *Robot
+	Ticks int
+
+	agents    []Agent
+	driver    *driver
+	visited   map[Point]bool
+	redirtied map[Point]int
+	home      map[Point]Point // the next cell on the way back to the dock from each cell
+	cleaned   int             // cells the robot has cleaned, counting again any the cat dirtied
+	stats     Stats
+}
+
+// NewSimulation sets up the robot to clean the room following strategy,
+// along with the room's cat if it has one.
+func NewSimulation(room *Room, robot *Robot, strategy CleaningStrategy) *Simulation {
+	sim := &Simulation{
+		Room:      room,
+		Robot:     robot,
+		driver:    &driver{strategy: strategy},
+		visited:   map[Point]bool{robot.Position: true},
+		redirtied: make(map[Point]int),
+		home:      stepsTowards(room, room.Dock),
+		stats:     Stats{TicksTo95: -1},
+	}
+
+	sim.agents = append(sim.agents, sim.driver)
+	if room.Cat != nil {
+		sim.agents = append(sim.agents, room.Cat)
+	}
+
+	return sim
+}
+
+// Add brings another agent into the room. It acts after those already there.
+func (sim *Simulation) Add(agent Agent) {
+	sim.agents = append(sim.agents, agent)
+}
+
+// Done reports whether the robot has finished cleaning.
+func (sim *Simulation) Done() bool {
+	return sim.driver.done
+}
+
+// Step advances the simulation one tick.
+func (sim *Simulation) Step() {
+	sim.Ticks++
+	for _, agent := range sim.agents {
+		agent.Tick(sim)
+	}
+	sim.checkCoverage()
+	sim.display()
+}
+
+// Run cleans the room: the robot follows the strategy's plan until it has
+// nothing left to do, then sweeps up any cells it missed and goes back to its
+// dock, while the other agents carry on around it. A room the robot can't
+// finish in a generous number of ticks, say one the cat keeps dirtying behind
+// it, is left as it is.
+func (sim *Simulation) Run() Stats {
+	startTime := time.Now()
+
+	// clean the starting cell
+	sim.clean()
+	sim.checkCoverage()
+	sim.display()
+
+	maxTicks := sim.Room.Width * sim.Room.Height * 100
+	for !sim.Done() && sim.Ticks < maxTicks {
+		sim.Step()
+	}
+
+	sim.stats.Ticks = sim.Ticks
+	sim.stats.Cleaned = sim.Room.CleanedCellCount
+	sim.stats.Cleanable = sim.Room.CleanableCellCount
+	sim.stats.Obstacles = len(sim.Robot.ObstaclesEncountered)
+	sim.stats.RedirtiedCells = len(sim.redirtied)
+	for p := range sim.redirtied {
+		if !sim.Room.Grid[p.X][p.Y].Cleaned {
+			sim.stats.LeftDirty++
+		}
+	}
+	sim.stats.Elapsed = time.Since(startTime)
+
+	return sim.stats
+}
+
+// Run cleans the room with the robot following strategy, with the room's
+// cat, if any, getting in the way.
+func Run(room *Room, robot *Robot, strategy CleaningStrategy) Stats {
+	return NewSimulation(room, robot, strategy).Run()
+}
+
+// clean cleans the robot's cell.
+func (sim *Simulation) clean() {
+	before := sim.Room.CleanedCellCount
+	Clean(sim.Robot, sim.Room)
+	sim.cleaned += sim.Room.CleanedCellCount - before
+}
+
+// move takes the robot into the next cell, cleaning it.
+func (sim *Simulation) move(next Point) {
+	robot := sim.Robot
+
+	robot.Battery.Use(moveCost(robot.Position, next, robot.Direction))
+
+	heading := headingTo(robot.Position, next)
+	if !sameHeading(heading, robot.Direction) {
+		sim.stats.Turns++
+		robot.Direction = heading
+	}
+
+	if sim.visited[next] {
+		sim.stats.Revisits++
+	}
+	sim.visited[next] = true
+
+	// update robot position
+	robot.Position = next
+	robot.Path = append(robot.Path, next)
+
+	sim.clean()
+	sim.stats.Moves++
+}
+
+// headingTo returns the heading from one cell to the next, in degrees
+// measured from east towards south, from 0 up to 360.
+func headingTo(from, to Point) float64 {
+	dx, dy := to.X-from.X, to.Y-from.Y
+	return normalizeHeading(math.Atan2(float64(dy), float64(dx)) * 180 / math.Pi)
+}
+
+// normalizeHeading brings a heading in degrees into the range 0 up to 360,
+// so -90 and 270, say, are the same heading.
+func normalizeHeading(degrees float64) float64 {
+	degrees = math.Mod(degrees, 360)
+	if degrees < 0 {
+		degrees += 360
+	}
+	return degrees
+}
+
+// sameHeading reports whether two headings in degrees point the same way.
+func sameHeading(a, b float64) bool {
+	return normalizeHeading(a) == normalizeHeading(b)
+}
+
+// costHome is the charge the robot needs to get back to the dock from p,
+// arriving there facing heading: the moves and turns along the way it would
+// take, and a little to spare for going around the cat. It is 0 for a cell
+// the dock can't be reached from.
+func (sim *Simulation) costHome(p Point, heading float64) float64 {
+	path := sim.pathHome(p)
+	if path == nil {
+		return 0
+	}
+	return pathCost(path, heading) + homeMargin
+}
+
+// inRange reports whether the robot could get from the dock to p and back
+// on a full battery. Getting there takes about as much charge as getting
+// back, and both ways keep some to spare, since the way there may not be
+// quite the shortest.
+func (sim *Simulation) inRange(p Point) bool {
+	path := sim.pathHome(p)
+	if path == nil {
+		return false
+	}
+	if len(path) == 1 {
+		return true
+	}
+	return 2*sim.costHome(p, headingTo(path[0], path[1])) <= sim.Robot.Battery.Capacity
+}
+
+// pathHome returns the shortest way from p back to the dock, including
+// both, or nil if there is none.
+func (sim *Simulation) pathHome(p Point) []Point {
+	if _, ok := sim.home[p]; !ok {
+		return nil
+	}
+	path := []Point{p}
+	for p != sim.Room.Dock {
+		p = sim.home[p]
+		path = append(path, p)
+	}
+	return path
+}
+
+// pathCost is the charge it takes to move along path, facing heading at its
+// first cell.
+func pathCost(path []Point, heading float64) float64 {
+	cost := 0.0
+	for i := 1; i < len(path); i++ {
+		cost += moveCost(path[i-1], path[i], heading)
+		heading = headingTo(path[i-1], path[i])
+	}
+	return cost
+}
+
+// stepsTowards returns, for every cell goal can be reached from, the next
+// cell on a shortest way there. Furniture doesn't move, so the ways back to
+// the dock are only worked out once.
+func stepsTowards(room *Room, goal Point) map[Point]Point {
+	steps := map[Point]Point{goal: goal}
+	queue := []Point{goal}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, dir := range directions {
+			prev := Point{X: p.X + dir[0], Y: p.Y + dir[1]}
+			if _, seen := steps[prev]; seen || !room.IsValid(prev.X, prev.Y) {
+				continue
+			}
+			steps[prev] = p
+			queue = append(queue, prev)
+		}
+	}
+	return steps
+}
+
+// pathToNearest finds the fewest moves from start to the nearest cell that
+// want reports true for, not including start, or nil if none can be
+// reached. When through is given, the way there only goes through cells it
+// reports true for.
+func pathToNearest(room *Room, start Point, want, through func(Point) bool) []Point {
+	cameFrom := map[Point]Point{start: start}
+	queue := []Point{start}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if p != start && want(p) {
+			var path []Point
+			for ; p != start; p = cameFrom[p] {
+				path = append(path, p)
+			}
+			slices.Reverse(path)
+			return path
+		}
+		for _, dir := range directions {
+			next := Point{X: p.X + dir[0], Y: p.Y + dir[1]}
+			if _, seen := cameFrom[next]; seen || !room.IsValid(next.X, next.Y) {
+				continue
+			}
+			if through != nil && !through(next) {
+				continue
+			}
+			cameFrom[next] = p
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
+
+// checkCoverage notes the first tick 95% of the room is clean.
+func (sim *Simulation) checkCoverage() {
+	if sim.stats.TicksTo95 < 0 && sim.Room.CleanedCellCount*100 >= sim.Room.CleanableCellCount*95 {
+		sim.stats.TicksTo95 = sim.Ticks
+	}
+}
+
+// redirty records the cat walking dirt into a clean cell.
+func (sim *Simulation) redirty(p Point) {
+	sim.redirtied[p]++
+	sim.stats.Redirtied++
+}
+
+func (sim *Simulation) display() {
+	if sim.Room.Animate {
+		sim.Room.Display(sim.Robot, sim.Room.Cat, false)
+		time.Sleep(moveDelay)
+	}
+}
+
+// driver is the agent moving the robot: it follows the strategy's plans a
+// cell a tick, then sweeps up the cells still dirty, waiting for the cat or
+// going around it when it is in the way. Before the battery gets too low to
+// make it back, it goes to the dock to charge, then picks up where it left
+// off.
+type driver struct {
+	strategy   CleaningStrategy
+	plan       []Point
+	sweeping   bool
+	sweepStart int            // cells cleaned before the sweep began
+	skips      int            // plans skipped since the robot last cleaned a cell
+	skipped    map[Point]bool // cells given up on as beyond the battery's reach
+	waited     int
+	returning  bool    // heading for the dock
+	charging   bool    // on the dock, charging
+	resume     []Point // the plan to carry on with once charged
+	resumeFrom Point   // where the robot left off
+	resuming   bool    // on the way back to where it left off
+	finished   bool    // nothing left to clean
+	done       bool
+}
+
+func (d *driver) Tick(sim *Simulation) {
+	room, robot := sim.Room, sim.Robot
+	battery := robot.Battery
+
+	if d.charging {
+		battery.Recharge()
+		sim.stats.ChargingTicks++
+		if battery.Full() {
+			d.charging = false
+			d.resumeCleaning(sim)
+		}
+		return
+	}
+
+	for range maxReplans {
+		if len(d.plan) == 0 {
+			if d.returning {
+				d.dock(sim)
+				return
+			}
+			if !d.replan(sim) {
+				d.finished = true
+				d.returnToDock(sim)
+			}
+			continue
+		}
+
+		// stop short of a blocked cell, and plan again from here
+		next := d.plan[0]
+		if !room.IsValid(next.X, next.Y) {
+			d.plan = nil
+			d.resuming = false
+			continue
+		}
+
+		// wait for the cat to get out of the way, going around it if it
+		// takes too long
+		if cat := room.Cat; IsAdjacentToCat(robot, cat) && cat.Position == next {
+			sim.stats.Waits++
+			d.waited++
+			if d.waited >= maxCatWait {
+				d.detour(sim)
+			}
+			return
+		}
+		d.waited = 0
+
+		// leave cells too far from the dock to reach even on a full
+		// battery, and clean what can be reached instead
+		if !d.returning && !sim.inRange(next) {
+			d.skip(next)
+			continue
+		}
+
+		// turn back while there is still the charge to get to the dock
+		cost := moveCost(robot.Position, next, robot.Direction)
+		if !d.returning && battery.Charge < cost+sim.costHome(next, headingTo(robot.Position, next)) {
+			if robot.Position == room.Dock && battery.Full() || d.resuming && robot.Position == d.resumeFrom {
+				// just charged, and still not enough to go on
+				d.skip(next)
+				continue
+			}
+			d.returnToDock(sim)
+			continue
+		}
+		if battery.Charge < cost {
+			sim.stats.OutOfCharge = true
+			d.done = true
+			return
+		}
+
+		if robot.Position == d.resumeFrom {
+			d.resuming = false
+		}
+
+		d.plan = d.plan[1:]
+		cleaned := sim.cleaned
+		sim.move(next)
+		if sim.cleaned > cleaned {
+			d.skips = 0
+		}
+		return
+	}
+}
+
+// replan asks the strategy where to go next, or once it has nothing left,
+// heads for the next cell still dirty. It returns false when there is
+// nowhere left to go.
+func (d *driver) replan(sim *Simulation) bool {
+	room, robot := sim.Room, sim.Robot
+
+	// a strategy that keeps heading beyond the battery's reach is left to
+	// the sweep, which only goes where the battery allows
+	if !d.sweeping {
+		if d.skips < maxReplans {
+			if path, ok := d.strategy.Next(room, robot); ok {
+				d.plan = path
+				return true
+			}
+		}
+		d.sweeping = true
+		d.sweepStart = sim.cleaned
+	}
+
+	// sweep up the cells still dirty, nearest first, which picks up any the
+	// cat walks dirt back into behind the robot. A cat can keep that up
+	// forever, so the sweep gives up after cleaning as many cells as the
+	// room has
+	dirty := func(p Point) bool {
+		return !room.Grid[p.X][p.Y].Cleaned
+	}
+	reachable := func(p Point) bool {
+		return !d.skipped[p] && sim.inRange(p)
+	}
+	var path []Point
+	if sim.cleaned-d.sweepStart < room.CleanableCellCount {
+		path = pathToNearest(room, robot.Position, func(p Point) bool {
+			return dirty(p) && (room.Cat == nil || p != room.Cat.Position)
+		}, reachable)
+	}
+	if len(path) == 0 {
+		// it is stranded if what it leaves dirty is beyond its reach
+		sim.stats.Stranded = len(pathToNearest(room, robot.Position, func(p Point) bool {
+			return dirty(p) && !reachable(p)
+		}, nil)) > 0
+		return false
+	}
+
+	d.plan = path
+	return true
+}
+
+// skip gives up on the plan, and on p, the cell in it beyond the battery's
+// reach, leaving the strategy or the sweep to choose somewhere else.
+func (d *driver) skip(p Point) {
+	if d.skipped == nil {
+		d.skipped = make(map[Point]bool)
+	}
+	d.skipped[p] = true
+	d.plan = nil
+	d.skips++
+}
+
+// returnToDock heads for the dock, keeping the plan the robot was following
+// to carry on with after charging.
+func (d *driver) returnToDock(sim *Simulation) {
+	robot := sim.Robot
+
+	if !d.returning {
+		d.returning = true
+		d.resume, d.resumeFrom = d.plan, robot.Position
+	}
+	d.resuming = false
+
+	d.plan = nil
+	if path := sim.pathHome(robot.Position); len(path) > 1 {
+		d.plan = path[1:]
+	}
+}
+
+// dock stops the robot at the end of its way back to the dock, to charge,
+// or for good once it has finished cleaning.
+func (d *driver) dock(sim *Simulation) {
+	d.returning = false
+
+	switch {
+	case sim.Robot.Position != sim.Room.Dock:
+		// there is no way back
+		sim.stats.Stranded = true
+		d.done = true
+	case d.finished:
+		d.done = true
+	default:
+		d.charging = true
+		sim.stats.Charges++
+	}
+}
+
+// resumeCleaning takes the robot from the dock back to where it left off,
+// the way it came home, which the charge it keeps for getting home was
+// worked out along.
+func (d *driver) resumeCleaning(sim *Simulation) {
+	d.plan = d.resume
+	if path := sim.pathHome(d.resumeFrom); len(path) > 1 {
+		slices.Reverse(path)
+		d.plan = append(path[1:], d.resume...)
+	}
+	d.resume = nil
+	d.resuming = true
+}
+
+// detour plans a way around the cat to where the robot was heading. With
+// no way around, the robot goes somewhere else instead, since the cat may
+// be boxed in behind it. On the way back to the dock the charge kept for it
+// may not cover going the long way round, so unless the battery has enough
+// for the whole detour, the robot keeps waiting for the cat to move, which
+// takes no charge.
+func (d *driver) detour(sim *Simulation) {
+	robot, cat := sim.Robot, sim.Room.Cat
+
+	var path []Point
+	if goal := d.plan[len(d.plan)-1]; goal != cat.Position {
+		path = AStarAvoiding(sim.Room, robot.Position, goal, func(p Point) bool {
+			return p == cat.Position
+		})
+	}
+	if d.returning {
+		if len(path) < 2 || pathCost(path, robot.Direction) > robot.Battery.Charge {
+			return
+		}
+	} else if len(path) < 2 {
+		d.plan = nil
+		d.waited = 0
+		d.resuming = false
+		return
+	}
+
+	d.plan = path[1:]
+	d.waited = 0
+	d.resuming = false
+}
@@ -0,0 +1,222 @@
+package robotsim
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// testRoom builds a walled room width by height cells, with the robot's
+// dock in the top left corner and repeatable randomness.
+func testRoom(tb testing.TB, width, height int, furniture ...Furniture) *Room {
+	room, err := buildRoom(&RoomConfig{Width: width * cellSize, Height: height * cellSize, Furniture: furniture}, false)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	room.SetSeed(1)
+	return room
+}
+
+// table is a piece of furniture in the middle of a room, in cells.
+func table(x, y, width, height int) Furniture {
+	return Furniture{X: x * cellSize, Y: y * cellSize, Width: width * cellSize, Height: height * cellSize, Name: "table", Type: "furniture"}
+}
+
+func TestSimulationCoverage(t *testing.T) {
+	rooms := []struct {
+		name string
+		room func(tb testing.TB) *Room
+	}{
+		{"empty", func(tb testing.TB) *Room { return testRoom(tb, 12, 9) }},
+		{"furnished", func(tb testing.TB) *Room { return testRoom(tb, 16, 12, table(4, 3, 3, 2), table(9, 7, 4, 3)) }},
+		{"corridor", func(tb testing.TB) *Room { return testRoom(tb, 20, 3) }},
+	}
+
+	for _, r := range rooms {
+		for _, name := range StrategyNames() {
+			t.Run(r.name+"/"+name, func(t *testing.T) {
+				room := r.room(t)
+				robot := NewDockedRobot(room)
+				strategy, _ := LookupStrategy(name)
+				sim := NewSimulation(room, robot, strategy.New(room, robot))
+				stats := sim.Run()
+
+				if !sim.Done() {
+					t.Fatalf("still cleaning after %d ticks", stats.Ticks)
+				}
+				if stats.Cleanable != room.CleanableCellCount || stats.Cleaned != stats.Cleanable {
+					t.Errorf("cleaned %d of %d cells, want all %d", stats.Cleaned, stats.Cleanable, room.CleanableCellCount)
+				}
+				if stats.Coverage() != 100 {
+					t.Errorf("coverage %.2f%%, want 100%%", stats.Coverage())
+				}
+				if stats.Ticks != sim.Ticks {
+					t.Errorf("stats count %d ticks, the simulation %d", stats.Ticks, sim.Ticks)
+				}
+				if stats.Moves < stats.Cleanable-1 || stats.Moves > stats.Ticks {
+					t.Errorf("%d moves in %d ticks to clean %d cells", stats.Moves, stats.Ticks, stats.Cleanable)
+				}
+				if stats.TicksTo95 < 0 || stats.TicksTo95 > stats.Ticks {
+					t.Errorf("reached 95%% at tick %d of %d", stats.TicksTo95, stats.Ticks)
+				}
+				if robot.Position != room.Dock {
+					t.Errorf("finished at %v, want the dock at %v", robot.Position, room.Dock)
+				}
+			})
+		}
+	}
+}
+
+// countingAgent counts the ticks it is given.
+type countingAgent struct {
+	ticks int
+}
+
+func (a *countingAgent) Tick(*Simulation) {
+	a.ticks++
+}
+
+func TestSimulationStep(t *testing.T) {
+	for _, steps := range []int{0, 1, 5, 20} {
+		t.Run(fmt.Sprint(steps), func(t *testing.T) {
+			room := testRoom(t, 12, 9)
+			robot := NewDockedRobot(room)
+			strategy, _ := LookupStrategy("snake")
+			sim := NewSimulation(room, robot, strategy.New(room, robot))
+			agent := &countingAgent{}
+			sim.Add(agent)
+
+			for range steps {
+				sim.Step()
+			}
+
+			if sim.Ticks != steps || agent.ticks != steps {
+				t.Errorf("after %d steps the simulation is at tick %d and the agent had %d", steps, sim.Ticks, agent.ticks)
+			}
+		})
+	}
+}
+
+// TestCatRedirties lets a cat loose in a room that is already clean, with
+// the robot staying on its dock. Every cell the cat steps into is clean
+// until it does, and stays dirty, so the cells dirtied are the cells it
+// walked into.
+func TestCatRedirties(t *testing.T) {
+	for seed := range int64(5) {
+		t.Run(fmt.Sprint("seed ", seed), func(t *testing.T) {
+			room := testRoom(t, 12, 9, table(6, 4, 2, 2))
+			room.SetSeed(seed)
+			for x := range room.Grid {
+				for y := range room.Grid[x] {
+					room.Grid[x][y].Cleaned = !room.Grid[x][y].Obstacle
+				}
+			}
+			room.CleanedCellCount = room.CleanableCellCount
+
+			room.Cat = NewCat(room)
+			robot := NewDockedRobot(room)
+			strategy, _ := LookupStrategy("snake")
+			sim := NewSimulation(room, robot, strategy.New(room, robot))
+			for range 60 {
+				room.Cat.Tick(sim)
+			}
+
+			walked := make(map[Point]bool)
+			for _, p := range room.Cat.Path[1:] {
+				walked[p] = true
+			}
+			if len(walked) == 0 {
+				t.Fatal("the cat never moved")
+			}
+			if len(sim.redirtied) != len(walked) || sim.stats.Redirtied != len(walked) {
+				t.Errorf("cat dirtied %d cells %d times, want %d cells once each", len(sim.redirtied), sim.stats.Redirtied, len(walked))
+			}
+			if dirty := room.CleanableCellCount - room.CleanedCellCount; dirty != len(walked) {
+				t.Errorf("%d cells dirty, want %d", dirty, len(walked))
+			}
+			for p := range walked {
+				if room.Grid[p.X][p.Y].Cleaned {
+					t.Errorf("the cat walked into %v and left it clean", p)
+				}
+			}
+		})
+	}
+}
+
+// TestCatTurnsAround walks a cat up and down a corridor one cell wide, where
+// most of its moves are blocked. Each time one is, it stays put and heads
+// back the way it came, rather than stopping dead.
+func TestCatTurnsAround(t *testing.T) {
+	room := testRoom(t, 10, 3)
+	robot := NewDockedRobot(room)
+	strategy, _ := LookupStrategy("snake")
+	sim := NewSimulation(room, robot, strategy.New(room, robot))
+	cat := &Cat{Position: Point{X: 5, Y: 1}, Active: true, DirectionX: 1}
+	cat.Path = []Point{cat.Position}
+	room.Cat = cat
+
+	var blocked int
+	for range 500 {
+		before := *cat
+		cat.Tick(sim)
+		if before.StopTimer > 0 || cat.StopTimer > 0 || cat.Position != before.Position {
+			continue
+		}
+
+		blocked++
+		if cat.DirectionX == 0 && cat.DirectionY == 0 {
+			t.Fatalf("cat blocked at %v stopped dead", cat.Position)
+		}
+		back := Point{X: cat.Position.X - cat.DirectionX, Y: cat.Position.Y - cat.DirectionY}
+		if room.IsValid(back.X, back.Y) && back != robot.Position {
+			t.Fatalf("cat at %v turned to head %d,%d, but %v it turned from is open", cat.Position, cat.DirectionX, cat.DirectionY, back)
+		}
+	}
+	if blocked == 0 || len(cat.Path) < 20 {
+		t.Errorf("cat was blocked %d times and moved %d times, want both often", blocked, len(cat.Path)-1)
+	}
+}
+
+func TestStatsRatios(t *testing.T) {
+	tests := []struct {
+		name           string
+		stats          Stats
+		wantCoverage   float64
+		wantEfficiency float64
+	}{
+		{"nothing to clean", Stats{}, 0, 0},
+		{"no moves", Stats{Cleaned: 1, Cleanable: 10}, 10, 0},
+		{"half clean", Stats{Cleaned: 5, Cleanable: 10, Moves: 20}, 50, 0.25},
+		{"all clean", Stats{Cleaned: 8, Cleanable: 8, Moves: 8}, 100, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.stats.Coverage(); got != tt.wantCoverage {
+				t.Errorf("Coverage() = %v, want %v", got, tt.wantCoverage)
+			}
+			if got := tt.stats.Efficiency(); got != tt.wantEfficiency {
+				t.Errorf("Efficiency() = %v, want %v", got, tt.wantEfficiency)
+			}
+		})
+	}
+}
+
+// TestStatsNoMoves runs a robot whose dock is the only floor in the room,
+// so it never moves.
+func TestStatsNoMoves(t *testing.T) {
+	room := testRoom(t, 3, 3)
+	robot := NewDockedRobot(room)
+	strategy, _ := LookupStrategy("snake")
+	stats := Run(room, robot, strategy.New(room, robot))
+
+	if stats.Moves != 0 {
+		t.Fatalf("%d moves in a room with nowhere to go", stats.Moves)
+	}
+	if math.IsNaN(stats.Efficiency()) || stats.Efficiency() != 0 {
+		t.Errorf("Efficiency() = %v, want 0", stats.Efficiency())
+	}
+	if stats.Coverage() != 100 {
+		t.Errorf("Coverage() = %v, want 100", stats.Coverage())
+	}
+}
@@ -0,0 +1,152 @@
+package robotsim
+
+import "math"
+
+// slam maps the room as the robot cleans it, always heading for the closest
+// cell it knows is free but hasn't visited.
+type slam struct {
+	robotMap [][]int
+	visited  map[Point]bool
+	frontier map[Point]bool
+	seen     int // how much of the robot's path has been mapped
+}
+
+func newSlam(room *Room, robot *Robot) CleaningStrategy {
+	return &slam{
+		// initialize the robots internal map
+		robotMap: initializeRobotMap(room.Width, room.Height),
+		// initialize visited cells (tracking) and a frontier
+		visited:  make(map[Point]bool),
+		frontier: make(map[Point]bool),
+	}
+}
+
+func (s *slam) Next(room *Room, robot *Robot) ([]Point, bool) {
+	// map every cell moved through since the last plan, marking it visited
+	// and adding its neighbors to the frontier
+	for ; s.seen < len(robot.Path); s.seen++ {
+		position := robot.Path[s.seen]
+		s.visited[position] = true
+		updateRobotMap(position, s.robotMap, room)
+		addNeighborsToFrontier(position, s.robotMap, s.frontier, s.visited, room)
+	}
+
+	// every 10 moves, do a more thorough frontier check
+	if moveCount := len(robot.Path) - 1; moveCount > 0 && moveCount%10 == 0 {
+		updateAllFrontiers(s.robotMap, s.frontier, s.visited, room)
+	}
+
+	// check if we have sufficient coverage
+	if float64(room.CleanedCellCount)/float64(room.CleanableCellCount) > 0.95 {
+		return nil, false
+	}
+
+	// if the frontier is not empty, head for the closest frontier point
+	for len(s.frontier) > 0 {
+		target := getClosestFrontierPoint(robot.Position, s.frontier)
+
+		// remove target from frontier
+		delete(s.frontier, target)
+
+		// find path to target using astar, going to the next frontier point
+		// if there is none
+		path := AStar(room, robot.Position, target)
+		if len(path) <= 1 {
+			continue
+		}
+
+		return path[1:], true
+	}
+
+	return nil, false
+}
+
+func initializeRobotMap(width, height int) [][]int {
+	// 0 = unknown, 1 = free, 2 = obstacle, 3 = cleaned
+	robotMap := make([][]int, width)
+	for i := range robotMap {
+		robotMap[i] = make([]int, height)
+	}
+
+	return robotMap
+}
+
+func updateRobotMap(position Point, robotMap [][]int, room *Room) {
+	if room.Grid[position.X][position.Y].Cleaned {
+		robotMap[position.X][position.Y] = 3
+	} else {
+		robotMap[position.X][position.Y] = 1
+	}
+
+	// scan surroundings
+	for _, dir := range directions {
+		newX, newY := position.X+dir[0], position.Y+dir[1]
+
+		// check if position is within bounds
+		if newX >= 0 && newX < len(robotMap) && newY >= 0 && newY < len(robotMap[0]) {
+			if room.Grid[newX][newY].Obstacle {
+				robotMap[newX][newY] = 2
+			} else if robotMap[newX][newY] == 0 {
+				robotMap[newX][newY] = 1
+			} else if room.Grid[newX][newY].Cleaned {
+				robotMap[newX][newY] = 3
+			}
+		}
+	}
+}
+
+func addNeighborsToFrontier(
+	position Point,
+	robotMap [][]int,
+	frontier map[Point]bool,
+	visited map[Point]bool,
+	room *Room,
+) {
+	// chech adjacent cells
+	for _, dir := range directions {
+		newX, newY := position.X+dir[0], position.Y+dir[1]
+		newPoint := Point{X: newX, Y: newY}
+
+		// check if position is valid, not visited, not an obstacle and not already in frontier
+		if newX >= 0 && newX < len(robotMap) && newY >= 0 && newY < len(robotMap[0]) &&
+			!visited[newPoint] && !frontier[newPoint] && room.IsValid(newX, newY) {
+			// add to frontier
+			frontier[newPoint] = true
+		}
+	}
+}
+
+func getClosestFrontierPoint(position Point, frontier map[Point]bool) Point {
+	closestPoint := Point{X: -1, Y: -1}
+	minDistance := math.MaxFloat64
+
+	for point := range frontier {
+		distance := heuristic(position, point)
+		if distance < minDistance {
+			minDistance = distance
+			closestPoint = point
+		}
+	}
+
+	return closestPoint
+}
+
+func updateAllFrontiers(robotMap [][]int, frontier map[Point]bool, visited map[Point]bool, room *Room) {
+	for x := 1; x < room.Width-1; x++ {
+		for y := 1; y < room.Height-1; y++ {
+			// if a cell is free, but not visited, add to frontier
+			point := Point{X: x, Y: y}
+			if robotMap[x][y] == 1 && !visited[point] && !frontier[point] && !room.Grid[x][y].Obstacle {
+				// check to see if it is accesible (has at least 1 visited neighbor)
+				for _, dir := range directions {
+					nx, ny := x+dir[0], y+dir[1]
+					neighborPoint := Point{X: nx, Y: ny}
+					if nx >= 0 && nx < room.Width && ny < room.Height && ny >= 0 && visited[neighborPoint] {
+						frontier[point] = true
+						break
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package robotsim
+
+// snake sweeps the room in rows, back and forth.
+type snake struct {
+	points []Point
+	next   int
+}
+
+func newSnake(room *Room, robot *Robot) CleaningStrategy {
+	// generate the snaking pattern
+	return &snake{points: generateSnakingPattern(room)}
+}
+
+func (s *snake) Next(room *Room, robot *Robot) ([]Point, bool) {
+	// visit each point in the coverage pattern
+	for s.next < len(s.points) {
+		point := s.points[s.next]
+		s.next++
+
+		// skip cell if already clean
+		if room.Grid[point.X][point.Y].Cleaned {
+			continue
+		}
+
+		// find path to the next point
+		path := AStar(room, robot.Position, point)
+
+		// if no path found, try the next point
+		if len(path) <= 1 {
+			continue
+		}
+
+		return path[1:], true
+	}
+
+	return nil, false
+}
+
+func generateSnakingPattern(room *Room) []Point {
+	var points []Point
+	var directionX = 1
+
+	for y := 1; y < room.Height-1; y++ {
+		if directionX == 1 {
+			// moving left to right
+			for x := 1; x < room.Width-1; x++ {
+				if !room.Grid[x][y].Obstacle {
+					points = append(points, Point{X: x, Y: y})
+				}
+			}
+		} else {
+			// move right to left
+			for x := room.Width - 2; x >= 1; x-- {
+				if !room.Grid[x][y].Obstacle {
+					points = append(points, Point{X: x, Y: y})
+				}
+			}
+		}
+
+		directionX *= -1
+	}
+
+	return points
+}
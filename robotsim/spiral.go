@@ -0,0 +1,131 @@
+package robotsim
+
+// spiral heads for the middle of the room, then spirals outwards.
+type spiral struct {
+	center   Point
+	points   []Point
+	next     int
+	atCenter bool
+}
+
+func newSpiral(room *Room, robot *Robot) CleaningStrategy {
+	// find a valid point near the center of the room
+	center := findNearestCleanablePoint(room, Point{X: room.Width / 2, Y: room.Height / 2})
+
+	return &spiral{
+		center: center,
+		points: generateSpiralPattern(room, center),
+	}
+}
+
+func (s *spiral) Next(room *Room, robot *Robot) ([]Point, bool) {
+	// move to the center point first (A*)
+	if !s.atCenter {
+		s.atCenter = true
+		if path := AStar(room, robot.Position, s.center); len(path) > 1 {
+			return path[1:], true
+		}
+	}
+
+	// follow the spiral pattern
+	for s.next < len(s.points) {
+		point := s.points[s.next]
+		s.next++
+
+		// skip if cell is already clean, or an obstacle
+		if room.Grid[point.X][point.Y].Cleaned || room.Grid[point.X][point.Y].Obstacle {
+			continue
+		}
+
+		// find path to the next point (A*)
+		path := AStar(room, robot.Position, point)
+		if len(path) <= 1 {
+			continue
+		}
+
+		return path[1:], true
+	}
+
+	return nil, false
+}
+
+func findNearestCleanablePoint(room *Room, target Point) Point {
+	if room.IsValid(target.X, target.Y) && !room.Grid[target.X][target.Y].Obstacle {
+		return target
+	}
+
+	// search for a valid point in expanding circles
+	for radius := 1; radius < room.Width || radius < room.Height; radius++ {
+		// check all points at the current radius
+		for dx := -radius; dx <= radius; dx++ {
+			for dy := -radius; dy <= radius; dy++ {
+				if abs(dx) != radius && abs(dy) != radius {
+					continue
+				}
+
+				x, y := target.X+dx, target.Y+dy
+
+				// check to see if this point is valid and not a obstacle
+				if room.IsValid(x, y) && !room.Grid[x][y].Obstacle {
+					return Point{X: x, Y: y}
+				}
+			}
+		}
+	}
+
+	// if no valid point, return the starting point
+	return Point{X: 1, Y: 1}
+}
+
+func generateSpiralPattern(room *Room, center Point) []Point {
+	var points []Point
+
+	// maximum possible spiral size
+	maxSize := max(room.Width, room.Height)
+
+	// set delta x and delta y
+	dx := []int{1, 0, -1, 0}
+	dy := []int{0, 1, 0, -1}
+
+	// start at center
+	x, y := center.X, center.Y
+	dir := 0 // start moving right
+
+	// set spiral parameters
+	step := 1
+	stepCount := 0
+	dirChanges := 0
+
+	// generate the spiral pattern
+	for range maxSize * maxSize {
+		// add current point if valid
+		if room.IsValid(x, y) {
+			points = append(points, Point{X: x, Y: y})
+		}
+
+		// take a step
+		x += dx[dir]
+		y += dy[dir]
+		stepCount++
+
+		// check to see if we need to change direction
+		if stepCount == step {
+			dir = (dir + 1) % 4
+			stepCount = 0
+			dirChanges++
+
+			// increase step size after every two direction changes
+			if dirChanges == 2 {
+				step++
+				dirChanges = 0
+			}
+		}
+
+		// break if we are out of bounds
+		if x < 0 || x >= room.Width || y < 0 || y >= room.Height {
+			break
+		}
+	}
+
+	return points
+}
@@ -0,0 +1,71 @@
+package robotsim
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CleaningStrategy decides where the robot goes next. It only plans; Run
+// moves the robot along the plan, cleaning as it goes, and takes care of the
+// cat, the animation and the metrics.
+type CleaningStrategy interface {
+	// Next returns the cells the robot should move through next, not
+	// including the one it is on, or false once the strategy has nothing
+	// left to do. The robot stops short if a cell turns out to be blocked,
+	// and Next is asked again from wherever it stopped.
+	Next(room *Room, robot *Robot) ([]Point, bool)
+}
+
+// Strategy describes a cleaning algorithm that can be selected with
+// -algorithm. New starts a fresh plan for a room, since strategies keep
+// track of what they have covered.
+type Strategy struct {
+	Name        string
+	Description string
+	New         func(room *Room, robot *Robot) CleaningStrategy
+}
+
+// strategies holds every registered cleaning algorithm, keyed by the name
+// used on the command line.
+var strategies = map[string]Strategy{
+	"random": {
+		Name:        "random",
+		Description: "random walk in straight lines, heading for dirt when stuck",
+		New:         newRandomWalk,
+	},
+	"slam": {
+		Name:        "slam",
+		Description: "map the room while cleaning, always heading for the nearest unexplored cell",
+		New:         newSlam,
+	},
+	"snake": {
+		Name:        "snake",
+		Description: "sweep the room in rows, back and forth",
+		New:         newSnake,
+	},
+	"spiral": {
+		Name:        "spiral",
+		Description: "spiral outwards from the middle of the room",
+		New:         newSpiral,
+	},
+}
+
+// LookupStrategy returns the strategy registered under name.
+func LookupStrategy(name string) (Strategy, error) {
+	s, ok := strategies[name]
+	if !ok {
+		return Strategy{}, fmt.Errorf("invalid cleaning algorithm %q, must be one of: %s", name, strings.Join(StrategyNames(), ", "))
+	}
+	return s, nil
+}
+
+// StrategyNames returns the names of all registered strategies, sorted.
+func StrategyNames() []string {
+	var names []string
+	for name := range strategies {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,54 @@
+package robotsim
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLookupStrategy(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "random"},
+		{name: "slam"},
+		{name: "snake"},
+		{name: "spiral"},
+		{name: "zigzag", wantErr: true},
+		{name: "Snake", wantErr: true},
+		{name: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := LookupStrategy(tt.name)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("LookupStrategy(%q) found %q, want an error", tt.name, s.Name)
+				}
+				// the error lists what there is to choose from
+				for _, name := range StrategyNames() {
+					if !strings.Contains(err.Error(), name) {
+						t.Errorf("error %q does not list %s", err, name)
+					}
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Name != tt.name || s.Description == "" || s.New == nil {
+				t.Errorf("LookupStrategy(%q) = %+v", tt.name, s)
+			}
+		})
+	}
+}
+
+func TestStrategyNames(t *testing.T) {
+	want := []string{"random", "slam", "snake", "spiral"}
+	if got := StrategyNames(); !slices.Equal(got, want) {
+		t.Errorf("StrategyNames() = %v, want %v", got, want)
+	}
+}
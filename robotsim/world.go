@@ -1,8 +1,13 @@
-package main
+// Package robotsim simulates a robot vacuum cleaning rooms: the rooms and
+// houses it cleans, loaded from json configs, the robot and the cat that
+// gets in its way, A* pathfinding, and the cleaning algorithms.
+package robotsim
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"os"
 	"strings"
 	"time"
@@ -17,6 +22,7 @@ const (
 	charDirty          = "🟫"
 	charPath           = "🟢"
 	charCat            = "🐱" // Display character for cat
+	charDock           = "🔌"
 	catStopProbability = 0.1 // Probability of cat stopping
 	catStopDuration    = 5   // Duration cat stays still (in animation frames)
 	moveDelay          = 50 * time.Millisecond
@@ -34,87 +40,6 @@ type Cell struct {
 	ObstacleName string
 }
 
-type House struct {
-	Rooms []*Room
-}
-
-func NewHouse(configFile string, animate bool) *House {
-	houseConfig, err := LoadHouseConfig(configFile)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-
-	var house House
-	for _, room := range houseConfig {
-		// convert dimensions to grid cells
-		gridWidth := room.Width / cellSize
-		gridHeight := room.Height / cellSize
-
-		// create a grid
-		grid := make([][]Cell, gridWidth)
-		for i := range grid {
-			grid[i] = make([]Cell, gridHeight)
-			for j := range grid[i] {
-				grid[i][j] = Cell{
-					Type: "dirty",
-					Cleaned: false,
-					Obstacle: false,
-				}
-			}
-		}
-
-		// add walls
-		for i := range gridWidth {
-			grid[i][0] = Cell{Type: "wall", Cleaned: false, Obstacle: true, ObstacleName: "wall"}
-			grid[i][gridHeight-1] = Cell{Type: "wall", Cleaned: false, Obstacle: true, ObstacleName: "wall"}
-		}
-
-		// add walls
-		for j := range gridHeight {
-			grid[0][j] = Cell{Type: "wall", Cleaned: false, Obstacle: true, ObstacleName: "wall"}
-			grid[gridWidth-1][j] = Cell{Type: "wall", Cleaned: false, Obstacle: true, ObstacleName: "wall"}
-		}
-
-		// add furniture
-		for _, f := range room.Furniture {
-			x := f.X / cellSize
-			y := f.Y / cellSize
-			width := f.Width / cellSize
-			height := f.Height / cellSize
-
-			for i := x; i < x+width; i++ {
-				for j := y; j < y+height; j++ {
-					grid[i][j] = Cell{Type: "furniture", Cleaned: false, Obstacle: true, ObstacleName: f.Name}
-				}
-			}
-		}
-
-		// count cleanable cells
-		cleanableCellCount := 0
-		for i := range gridWidth {
-			for j := range gridHeight {
-				if !grid[i][j].Obstacle {
-					cleanableCellCount++
-				}
-			}
-		}
-
-		r := Room{
-			Grid: grid,
-			Width: gridWidth,
-			Height: gridHeight,
-			CleanableCellCount: cleanableCellCount,
-			CleanedCellCount: 0,
-			Animate: animate,
-		}
-
-		house.Rooms = append(house.Rooms, &r)
-	}
-
-	return &house
-}
-
 type Furniture struct {
 	X      int    `json:"x"`
 	Y      int    `json:"y"`
@@ -124,10 +49,21 @@ type Furniture struct {
 	Type   string `json:"type"`
 }
 
+// RobotConfig is where the robot lives in a room. Positions and sizes are in
+// cm, like the rest of the config.
+type RobotConfig struct {
+	Diameter       int     `json:"diameter"`
+	DockX          int     `json:"dockX"`
+	DockY          int     `json:"dockY"`
+	StartDirection float64 `json:"startDirection"` // degrees clockwise from east
+	Battery        float64 `json:"battery"`        // charge when full, in moves, if not the default
+}
+
 type RoomConfig struct {
-	Width     int         `json:"width"`
-	Height    int         `json:"height"`
-	Furniture []Furniture `json:"furniture"`
+	Width     int          `json:"width"`
+	Height    int          `json:"height"`
+	Robot     *RobotConfig `json:"robot"`
+	Furniture []Furniture  `json:"furniture"`
 }
 
 type Room struct {
@@ -138,16 +74,74 @@ type Room struct {
 	CleanedCellCount   int
 	Animate            bool
 	Cat                *Cat
+	Rand               *rand.Rand // where the cat and random strategies get their randomness
+	Dock               Point      // where the robot starts and charges, (1, 1) unless the config says
+	StartDirection     float64    // the way the robot faces on the dock
+	RobotDiameter      int        // in cm, or 0 if the config doesn't say
+	BatteryCapacity    float64    // charge the robot's battery holds
+}
+
+// House is a set of rooms, each cleaned in turn.
+type House struct {
+	Rooms []*Room
 }
 
-func NewRoom(configFile string, animate bool) *Room {
+// NewRoom builds a room from a json config file.
+func NewRoom(configFile string, animate bool) (*Room, error) {
 	// load from json config
 	roomConfig, err := LoadRoomConfig(configFile)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return nil, err
+	}
+
+	return buildRoom(roomConfig, animate)
+}
+
+// NewRooms builds the rooms in a json config file, which may hold either a
+// single room or a house's list of them.
+func NewRooms(configFile string, animate bool) ([]*Room, error) {
+	jsonData, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	if !bytes.HasPrefix(bytes.TrimSpace(jsonData), []byte("[")) {
+		room, err := NewRoom(configFile, animate)
+		if err != nil {
+			return nil, err
+		}
+		return []*Room{room}, nil
+	}
+
+	house, err := NewHouse(configFile, animate)
+	if err != nil {
+		return nil, err
+	}
+	return house.Rooms, nil
+}
+
+// NewHouse builds a house from a json config file holding a list of rooms.
+func NewHouse(configFile string, animate bool) (*House, error) {
+	houseConfig, err := LoadHouseConfig(configFile)
+	if err != nil {
+		return nil, err
 	}
 
+	var house House
+	for i := range houseConfig {
+		room, err := buildRoom(&houseConfig[i], animate)
+		if err != nil {
+			return nil, fmt.Errorf("room %d: %w", i+1, err)
+		}
+		house.Rooms = append(house.Rooms, room)
+	}
+
+	return &house, nil
+}
+
+// buildRoom turns a room's config into its grid of cells, walled in and
+// with its furniture and the robot's dock in place.
+func buildRoom(roomConfig *RoomConfig, animate bool) (*Room, error) {
 	// convert dimensions of the room to grid cells
 	gridWidth := roomConfig.Width / cellSize
 	gridHeight := roomConfig.Height / cellSize
@@ -197,14 +191,46 @@ func NewRoom(configFile string, animate bool) *Room {
 		}
 	}
 
-	return &Room{
+	room := &Room{
 		Grid:               grid,
 		Width:              gridWidth,
 		Height:             gridHeight,
 		CleanableCellCount: cleanableCellCount,
 		CleanedCellCount:   0,
 		Animate:            animate,
+		Rand:               rand.New(rand.NewSource(time.Now().UnixNano())),
+		Dock:               Point{X: 1, Y: 1},
+		BatteryCapacity:    defaultBatteryCapacity,
 	}
+
+	// place the dock
+	if rc := roomConfig.Robot; rc != nil {
+		room.Dock = Point{X: rc.DockX / cellSize, Y: rc.DockY / cellSize}
+		room.StartDirection = rc.StartDirection
+		room.RobotDiameter = rc.Diameter
+		if rc.Battery > 0 {
+			room.BatteryCapacity = rc.Battery
+		}
+	}
+	if !room.IsValid(room.Dock.X, room.Dock.Y) {
+		return nil, fmt.Errorf("dock at (%d, %d) cm is not on the floor", room.Dock.X*cellSize, room.Dock.Y*cellSize)
+	}
+
+	return room, nil
+}
+
+// PutOnDock puts the robot on the room's dock, facing the way the config
+// says, with a fresh battery charged full.
+func (room *Room) PutOnDock(robot *Robot) {
+	robot.Position = room.Dock
+	robot.Path = []Point{room.Dock}
+	robot.Direction = normalizeHeading(room.StartDirection)
+	robot.Battery = NewBattery(room.BatteryCapacity)
+}
+
+// SetSeed makes the room's randomness repeatable.
+func (room *Room) SetSeed(seed int64) {
+	room.Rand = rand.New(rand.NewSource(seed))
 }
 
 func (room *Room) Display(robot *Robot, cat *Cat, showPath bool) {
@@ -222,6 +248,8 @@ func (room *Room) Display(robot *Robot, cat *Cat, showPath bool) {
 				fmt.Print(charCat)
 			} else if showPath && isInPath(Point{X: i, Y: j}, robot.Path) {
 				fmt.Print(charPath)
+			} else if room.Dock.X == i && room.Dock.Y == j {
+				fmt.Print(charDock)
 			} else {
 				cell := room.Grid[i][j]
 				switch cell.Type {
@@ -248,11 +276,15 @@ func (room *Room) Display(robot *Robot, cat *Cat, showPath bool) {
 		room.CleanableCellCount,
 	)
 
+	if battery := robot.Battery; battery != nil {
+		fmt.Printf("battery: %.0f%%\n", battery.Level())
+	}
+
 	if cat != nil {
 		fmt.Printf(
 			"robot position: (%d, %d), cat position: (%d, %d)\n",
 			robot.Position.X, robot.Position.Y,
-			cat.Position.X, cat.DirectionY,
+			cat.Position.X, cat.Position.Y,
 		)
 	}
 }
@@ -291,7 +323,7 @@ func LoadHouseConfig(filename string) ([]RoomConfig, error) {
 	}
 
 	return roomConf, nil
-} 
+}
 
 func isInPath(point Point, path []Point) bool {
 	for _, p := range path {
@@ -303,7 +335,9 @@ func isInPath(point Point, path []Point) bool {
 	return false
 }
 
-func displaySummary(room *Room, robot *Robot, moveCount int, cleaningTime time.Duration) {
+// DisplaySummary shows the room as the robot left it, with its path, and how
+// the cleaning went.
+func DisplaySummary(room *Room, robot *Robot, stats Stats) {
 	// display the final room state with the robot's path
 	fmt.Println("\nFinal room state with robot's path")
 	room.Display(robot, room.Cat, true)
@@ -319,22 +353,49 @@ func displaySummary(room *Room, robot *Robot, moveCount int, cleaningTime time.D
 		room.Height*cellSize,
 	)
 
+	// where the robot lives
+	fmt.Printf("dock: (%d, %d)", room.Dock.X, room.Dock.Y)
+	if room.RobotDiameter > 0 {
+		fmt.Printf(", robot %d cm across", room.RobotDiameter)
+	}
+	fmt.Println()
+
 	// calculate coverage percentage
-	percentCleaned := float64(room.CleanedCellCount) / float64(room.CleanableCellCount) * 100
 	fmt.Printf(
 		"coverage: %.2f%% (%d/%d cells cleaned)\n",
-		percentCleaned,
-		room.CleanedCellCount,
-		room.CleanableCellCount,
+		stats.Coverage(),
+		stats.Cleaned,
+		stats.Cleanable,
 	)
 
 	// display time and moves
-	fmt.Printf("total moves: %d\n", moveCount)
-	fmt.Printf("cleaning time: %v\n", cleaningTime)
+	fmt.Printf("total moves: %d in %d ticks\n", stats.Moves, stats.Ticks)
+	fmt.Printf("cleaning time: %v\n", stats.Elapsed)
 
 	// calculate efficiency (cells cleaned per move)
-	efficiency := float64(room.CleanedCellCount) / float64(moveCount)
-	fmt.Printf("efficiency: %.2f cells cleaned per move\n", efficiency)
+	fmt.Printf("efficiency: %.2f cells cleaned per move\n", stats.Efficiency())
+
+	// display how the battery held up
+	if robot.Battery != nil {
+		fmt.Printf("charge cycles: %d, %d ticks spent charging\n", stats.Charges, stats.ChargingTicks)
+		if stats.Stranded {
+			fmt.Println("robot gave up: the cells left are too far from the dock for its battery")
+		}
+		if stats.OutOfCharge {
+			fmt.Println("robot ran out of charge before it got back to its dock")
+		}
+	}
+
+	// display what the cat got up to
+	if room.Cat != nil {
+		fmt.Printf(
+			"cat re-dirtied %d cells %d times, %d left dirty\n",
+			stats.RedirtiedCells,
+			stats.Redirtied,
+			stats.LeftDirty,
+		)
+		fmt.Printf("robot waited %d ticks for the cat\n", stats.Waits)
+	}
 
 	// display encountered obstacles
 	obstacles := getEncounteredObstacleList(robot)